@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: apikey.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:30:04
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:30:04
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// sourceCommand marks an API key that was printed by api_key_cmd.
+const sourceCommand = "command"
+
+// apiKeyCmdCache holds the output of every api_key_cmd run by this process,
+// so that the command is executed at most once per invocation.
+var apiKeyCmdCache = map[string]string{}
+
+// sourceRank orders setting sources by precedence; lower wins.
+func sourceRank(source string) int {
+	switch source {
+	case sourceFlag:
+		return 0
+	case sourceEnv:
+		return 1
+	case sourceProfile:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// resolveAPIKey settles the API key once the settings are resolved. The
+// api_key_cmd is used when it comes from a more specific source than
+// api_key (a literal key wins a tie), and the key saved by "see login" is
+// the last resort.
+func resolveAPIKey() error {
+	key, keyCmd := settings["api_key"], settings["api_key_cmd"]
+	if keyCmd.Value != "" && (key.Value == "" || sourceRank(keyCmd.Source) < sourceRank(key.Source)) {
+		v, err := apiKeyFromCommand(keyCmd.Value)
+		if err != nil {
+			return err
+		}
+		rootOpts.apiKey = v
+		settings["api_key"] = settingValue{Value: v, Source: sourceCommand}
+	}
+	return useStoredAPIKey()
+}
+
+// apiKeyFromCommand runs command through the shell and returns its trimmed
+// standard output. Standard error is passed through so that password
+// managers can ask for unlocking.
+func apiKeyFromCommand(command string) (string, error) {
+	if v, ok := apiKeyCmdCache[command]; ok {
+		return v, nil
+	}
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	var out bytes.Buffer
+	c.Stdout = &out
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("api_key_cmd failed: %w", err)
+	}
+
+	v := strings.TrimSpace(out.String())
+	if v == "" {
+		return "", errors.New("api_key_cmd printed no API key")
+	}
+	apiKeyCmdCache[command] = v
+	return v, nil
+}
@@ -0,0 +1,77 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: apikey_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:30:04
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:30:04
+//
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolveAPIKey_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	counter := filepath.Join(t.TempDir(), "runs")
+	command := "echo run >> " + counter + "; echo '  cmd-key  '"
+
+	tests := []struct {
+		name   string
+		key    settingValue
+		cmd    settingValue
+		want   string
+		source string
+	}{
+		{"command only", settingValue{}, settingValue{command, sourceProfile}, "cmd-key", sourceCommand},
+		{"env command beats profile key", settingValue{"profile-key", sourceProfile}, settingValue{command, sourceEnv}, "cmd-key", sourceCommand},
+		{"flag key beats env command", settingValue{"flag-key", sourceFlag}, settingValue{command, sourceEnv}, "flag-key", sourceFlag},
+		{"key wins a tie", settingValue{"profile-key", sourceProfile}, settingValue{command, sourceProfile}, "profile-key", sourceProfile},
+	}
+	for _, tt := range tests {
+		settings = map[string]settingValue{"api_key": tt.key, "api_key_cmd": tt.cmd}
+		unknownProfile = false
+		rootOpts.apiKey = tt.key.Value
+		if err := resolveAPIKey(); err != nil {
+			t.Fatalf("%s: resolveAPIKey failed: %v", tt.name, err)
+		}
+		if rootOpts.apiKey != tt.want || settings["api_key"].Source != tt.source {
+			t.Errorf("%s: expected %q from %s, got %q from %s", tt.name, tt.want, tt.source, rootOpts.apiKey, settings["api_key"].Source)
+		}
+	}
+
+	b, err := os.ReadFile(counter)
+	if err != nil {
+		t.Fatalf("read counter: %v", err)
+	}
+	if string(b) != "run\n" {
+		t.Errorf("expected api_key_cmd to run once, ran %q", b)
+	}
+	rootOpts.apiKey = ""
+}
+
+func TestAPIKeyFromCommand_Empty(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	if _, err := apiKeyFromCommand("true"); err == nil {
+		t.Error("expected error for empty output, got nil")
+	}
+	if _, err := apiKeyFromCommand("exit 3"); err == nil {
+		t.Error("expected error for failing command, got nil")
+	}
+}
@@ -0,0 +1,237 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: batch.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:04:38
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:04:38
+//
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultConcurrency is how many items of a batch are processed at once.
+const defaultConcurrency = 4
+
+// batchOpts holds the options of commands that send many requests.
+type batchOpts struct {
+	concurrency int
+	rate        rateValue
+}
+
+// addBatchFlags registers --concurrency and --rate on cmd. --rate is bound
+// to the rate setting, since the limit usually belongs to the account.
+func addBatchFlags(cmd *cobra.Command, opts *batchOpts) {
+	f := cmd.Flags()
+	f.IntVar(&opts.concurrency, "concurrency", defaultConcurrency, "Number of requests in flight at once")
+	f.Var(&opts.rate, "rate", "Most requests started per second, minute or hour, e.g. 5/s or 100/m (0 = no limit)")
+	bindSetting(f, "rate", "rate")
+}
+
+// check validates the options.
+func (o batchOpts) check() error {
+	if o.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	return nil
+}
+
+// rateValue is a request rate given as N, N/s, N/m or N/h. The zero value
+// means no limit.
+type rateValue struct {
+	n   float64
+	per time.Duration
+}
+
+func (r *rateValue) String() string {
+	if r.n == 0 {
+		return "0"
+	}
+	unit := map[time.Duration]string{time.Second: "s", time.Minute: "m", time.Hour: "h"}[r.per]
+	return strconv.FormatFloat(r.n, 'f', -1, 64) + "/" + unit
+}
+
+func (r *rateValue) Set(s string) error {
+	v, err := parseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+func (r *rateValue) Type() string { return "rate" }
+
+// perSecond returns the rate in requests per second.
+func (r rateValue) perSecond() float64 {
+	if r.n == 0 {
+		return 0
+	}
+	return r.n / r.per.Seconds()
+}
+
+// parseRate parses a rate such as 5/s, 100/m, 1000/h or a plain number of
+// requests per second.
+func parseRate(s string) (rateValue, error) {
+	num, unit, _ := strings.Cut(strings.TrimSpace(s), "/")
+	per := map[string]time.Duration{
+		"": time.Second, "s": time.Second, "sec": time.Second,
+		"m": time.Minute, "min": time.Minute,
+		"h": time.Hour, "hour": time.Hour,
+	}[unit]
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || per == 0 || n < 0 {
+		return rateValue{}, fmt.Errorf("invalid rate %q: expected e.g. 5/s, 100/m or 0 for no limit", s)
+	}
+	if n == 0 {
+		return rateValue{}, nil
+	}
+	return rateValue{n: n, per: per}, nil
+}
+
+// parseRateSetting validates the rate setting.
+func parseRateSetting(s string) (string, error) {
+	r, err := parseRate(s)
+	if err != nil {
+		return "", err
+	}
+	return r.String(), nil
+}
+
+// tokenBucket spaces out the start of requests. It holds up to burst tokens
+// and gains rate tokens per second; every request takes one.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a bucket for rate, or nil when there is no limit.
+// The burst is one second's worth of requests, so a full bucket never lets
+// through more than the rate allows over a second.
+func newTokenBucket(rate rateValue) *tokenBucket {
+	perSecond := rate.perSecond()
+	if perSecond == 0 {
+		return nil
+	}
+	burst := max(1, perSecond)
+	return &tokenBucket{rate: perSecond, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// take waits for a token. It reports false when stop is closed first.
+func (b *tokenBucket) take(stop <-chan struct{}) bool {
+	if b == nil {
+		return true
+	}
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens < 1 {
+		d := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-stop:
+			return false
+		}
+		b.tokens = 1
+		b.last = time.Now()
+	}
+	b.tokens--
+	return true
+}
+
+// runBatch calls do for the items 0 to n-1 with at most opts.concurrency
+// calls running and starts them no faster than opts.rate. done receives
+// every result in input order, on the calling goroutine, so output can be
+// written as it comes. Once done returns false no further items are started;
+// items already running still finish and are passed to done.
+func runBatch[T any](opts batchOpts, n int, do func(i int) T, done func(i int, v T) bool) {
+	type indexed struct {
+		i int
+		v T
+	}
+	workers := min(max(1, opts.concurrency), n)
+	var (
+		jobs    = make(chan int)
+		results = make(chan indexed)
+		stop    = make(chan struct{})
+		// slots are taken for each item started and given back once its
+		// result has been received, after done ran for whatever it allowed
+		// to be emitted. An item that fails therefore always stops the
+		// items after it from starting when items run one at a time.
+		slots  = make(chan struct{}, workers)
+		wg     sync.WaitGroup
+		bucket = newTokenBucket(opts.rate)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- indexed{i, do(i)}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := 0; i < n; i++ {
+			if !bucket.take(stop) {
+				return
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-stop:
+				return
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			jobs <- i
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Items are started in order, so every index below the highest one
+	// received arrives eventually and the pending results are emitted
+	// without gaps.
+	pending := map[int]T{}
+	next, stopped := 0, false
+	for r := range results {
+		pending[r.i] = r.v
+		for {
+			v, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			if !done(next, v) && !stopped {
+				stopped = true
+				close(stop)
+			}
+			next++
+		}
+		<-slots
+	}
+}
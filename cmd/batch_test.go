@@ -0,0 +1,124 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: batch_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:04:38
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:04:38
+//
+
+package cmd
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in        string
+		perSecond float64
+		str       string
+	}{
+		{"5/s", 5, "5/s"},
+		{"5", 5, "5/s"},
+		{"120/m", 2, "120/m"},
+		{"1800/h", 0.5, "1800/h"},
+		{"0.5/sec", 0.5, "0.5/s"},
+		{"0", 0, "0"},
+		{"0/m", 0, "0"},
+	}
+	for _, tt := range tests {
+		r, err := parseRate(tt.in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if r.perSecond() != tt.perSecond || r.String() != tt.str {
+			t.Errorf("%s: expected %v/s (%s), got %v/s (%s)", tt.in, tt.perSecond, tt.str, r.perSecond(), r.String())
+		}
+	}
+	for _, in := range []string{"", "fast", "5/d", "-1/s", "/s"} {
+		if _, err := parseRate(in); err == nil {
+			t.Errorf("%q: expected error, got nil", in)
+		}
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := &tokenBucket{rate: 100, burst: 1, tokens: 1, last: time.Now()}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if !b.take(nil) {
+			t.Fatal("expected a token")
+		}
+	}
+	// The first token is there already; the other four take 10ms each.
+	if d := time.Since(start); d < 35*time.Millisecond {
+		t.Errorf("expected the bucket to space out requests, took %v", d)
+	}
+
+	stop := make(chan struct{})
+	close(stop)
+	if b.take(stop) {
+		t.Error("expected take to give up once stopped")
+	}
+	if (*tokenBucket)(nil).take(nil) != true {
+		t.Error("expected a nil bucket not to limit")
+	}
+}
+
+func TestRunBatchOrder(t *testing.T) {
+	var running, peak atomic.Int32
+	var got []int
+	runBatch(batchOpts{concurrency: 3}, 10, func(i int) int {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// Later items finish first.
+		time.Sleep(time.Duration(10-i) * time.Millisecond)
+		running.Add(-1)
+		return i * i
+	}, func(i, v int) bool {
+		if v != i*i {
+			t.Errorf("item %d: expected %d, got %d", i, i*i, v)
+		}
+		got = append(got, i)
+		return true
+	})
+	if want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected results in input order, got %v", got)
+	}
+	if p := peak.Load(); p > 3 {
+		t.Errorf("expected at most 3 items at once, got %d", p)
+	}
+}
+
+func TestRunBatchStop(t *testing.T) {
+	var started atomic.Int32
+	var got []int
+	runBatch(batchOpts{concurrency: 1}, 10, func(i int) bool {
+		started.Add(1)
+		return i != 2
+	}, func(i int, ok bool) bool {
+		got = append(got, i)
+		return ok
+	})
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected to stop after item 2, got %v", got)
+	}
+	if n := started.Load(); int(n) != len(got) {
+		t.Errorf("expected every started item to be reported, started %d, got %v", n, got)
+	}
+}
@@ -0,0 +1,131 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: cache.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:49:55
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:52:34
+//
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultCacheTTL is how long cached API metadata is reused unless the
+// cache_ttl setting says otherwise.
+const defaultCacheTTL = time.Hour
+
+// cacheEntry is the on-disk layout of a cached API response.
+type cacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	BaseURL   string          `json:"base_url"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// cacheDir returns the directory for cached API data:
+// $XDG_CACHE_HOME/see, or ~/.cache/see.
+func cacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "see"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".cache", "see"), nil
+}
+
+// cachePath returns the cache file of name for the active profile, since
+// profiles may belong to different accounts.
+func cachePath(name string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, activeProfile, name+".json"), nil
+}
+
+// readCache decodes the cached value of name into v. It reports false when
+// there is no entry, the entry is older than ttl or was fetched from another
+// API base URL. A damaged cache is treated as a miss.
+func readCache(name string, ttl time.Duration, v any) bool {
+	path, err := cachePath(name)
+	if err != nil {
+		return false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return false
+	}
+	if e.BaseURL != rootOpts.baseURL || time.Since(e.FetchedAt) > ttl {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// writeCache stores v as the cached value of name.
+func writeCache(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(cacheEntry{FetchedAt: time.Now().UTC(), BaseURL: rootOpts.baseURL, Data: data})
+	if err != nil {
+		return err
+	}
+	path, err := cachePath(name)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b, 0600)
+}
+
+// cacheTTL returns the configured lifetime of cached metadata.
+func cacheTTL() time.Duration {
+	if d, err := time.ParseDuration(settings["cache_ttl"].Value); err == nil {
+		return d
+	}
+	return defaultCacheTTL
+}
+
+// cachedValue returns the cached value of name, or fetches it from the API
+// when the cache is missing, stale or --refresh is set. fromCache reports
+// whether the cache was used, so that callers can refetch once before
+// rejecting a value that might be new.
+func cachedValue[T any](name string, fetch func() (T, error)) (v T, fromCache bool, err error) {
+	if !rootOpts.refresh && readCache(name, cacheTTL(), &v) {
+		return v, true, nil
+	}
+	v, err = refreshCache(name, fetch)
+	return v, false, err
+}
+
+// refreshCache fetches a value from the API and stores it in the cache.
+func refreshCache[T any](name string, fetch func() (T, error)) (T, error) {
+	var zero T
+	if err := ensureClient(); err != nil {
+		return zero, err
+	}
+	v, err := fetch()
+	if err != nil {
+		return zero, err
+	}
+	// A cache that cannot be written only costs another request next time.
+	writeCache(name, v)
+	return v, nil
+}
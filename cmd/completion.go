@@ -0,0 +1,221 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: completion.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:58:15
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:19:14
+//
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the autocompletion script for the specified shell",
+	Long: `Generate the autocompletion script for the specified shell and print it to
+stdout, or install it for the current user with 'see completion install'.
+
+Completions cover commands and flags, domains and tag names from the local
+cache, and slugs and delete keys from the local ledger.`,
+	Example: `  source <(see completion bash)
+  see completion install`,
+	Args:        cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:   []string{"bash", "zsh", "fish", "powershell"},
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeCompletion(cmd.OutOrStdout(), args[0])
+	},
+}
+
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [bash|zsh|fish]",
+	Short: "Install the autocompletion script for the current user",
+	Long: `Write the autocompletion script to the location where the shell loads it
+from for the current user. The shell defaults to the one in $SHELL.
+
+  bash  $XDG_DATA_HOME/bash-completion/completions/see (needs bash-completion)
+  zsh   ~/.zfunc/_see (the directory must be in $fpath)
+  fish  $XDG_CONFIG_HOME/fish/completions/see.fish`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shell := filepath.Base(os.Getenv("SHELL"))
+		if len(args) > 0 {
+			shell = args[0]
+		}
+		path, err := completionInstallPath(shell)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := writeCompletion(&buf, shell); err != nil {
+			return err
+		}
+		if err := writeFileAtomic(path, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Installed %s completion to %s\n", shell, path)
+		switch shell {
+		case "bash":
+			fmt.Fprintln(out, "Start a new shell to use it. It requires the bash-completion package.")
+		case "zsh":
+			fmt.Fprintf(out, "Unless %s is already in your fpath, add this to ~/.zshrc before compinit:\n  fpath=(%s $fpath)\n", filepath.Dir(path), filepath.Dir(path))
+		case "fish":
+			fmt.Fprintln(out, "Start a new shell to use it.")
+		}
+		return nil
+	},
+}
+
+func init() {
+	completionCmd.AddCommand(completionInstallCmd)
+}
+
+// writeCompletion writes the completion script of a shell.
+func writeCompletion(w io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(w, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q: use bash, zsh, fish or powershell", shell)
+	}
+}
+
+// completionInstallPath returns where a shell loads user completions from.
+func completionInstallPath(shell string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate home directory: %w", err)
+	}
+	switch shell {
+	case "bash":
+		dir := os.Getenv("XDG_DATA_HOME")
+		if dir == "" {
+			dir = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dir, "bash-completion", "completions", "see"), nil
+	case "zsh":
+		return filepath.Join(home, ".zfunc", "_see"), nil
+	case "fish":
+		dir := os.Getenv("XDG_CONFIG_HOME")
+		if dir == "" {
+			dir = filepath.Join(home, ".config")
+		}
+		return filepath.Join(dir, "fish", "completions", "see.fish"), nil
+	case "", ".":
+		return "", fmt.Errorf("cannot detect the shell from $SHELL: name it, e.g. 'see completion install zsh'")
+	default:
+		return "", fmt.Errorf("cannot install completion for %q: use bash, zsh or fish, or redirect 'see completion %s' yourself", shell, shell)
+	}
+}
+
+// completionCache returns the cached value of name, however old, or the
+// zero value. Completions never fetch from the API: that may run
+// api_key_cmd or ask for a passphrase, which the shell would hide.
+func completionCache[T any](cmd *cobra.Command, name string) T {
+	var v T
+	if resolveSettings(cmd) == nil {
+		readCache(name, math.MaxInt64, &v)
+	}
+	return v
+}
+
+// completeArchiveFormats completes --archive of file upload.
+func completeArchiveFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{archiveZip, archiveTarGz}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTagIDs completes the comma-separated --tag-ids with tag IDs,
+// described by their names.
+func completeTagIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tags := completionCache[[]seesdk.Tag](cmd, "tags")
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	var out []string
+	for _, t := range tags {
+		out = append(out, prefix+strconv.Itoa(t.ID)+"\t"+t.Name)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeSlugs returns a completion function for the slug argument of a
+// kind of content. Slugs come from the live ledger entries on the domain
+// selected by --domain.
+func completeSlugs(kind string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 || resolveSettings(cmd) != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		domain, _ := cmd.Flags().GetString("domain")
+		l, err := loadLedger()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var out []string
+		for _, r := range l.Records {
+			if r.Kind != kind || r.DeletedAt != nil || r.Slug == "" || (domain != "" && r.Domain != domain) {
+				continue
+			}
+			desc := r.Title
+			if kind == kindShortURL && r.Target != "" {
+				desc = r.Target
+			}
+			out = append(out, r.Slug+"\t"+desc)
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeDeleteKeys completes the delete key arguments of file delete and
+// file download from the ledger. Keys already on the command line are left
+// out.
+func completeDeleteKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if resolveSettings(cmd) != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []string
+	add := func(key, filename string) {
+		if key != "" && !slices.Contains(args, key) {
+			out = append(out, key+"\t"+filename)
+		}
+	}
+	if l, err := loadLedger(); err == nil {
+		for _, r := range l.Records {
+			if r.Kind == kindFile && r.DeletedAt == nil {
+				add(r.DeleteKey, r.Filename)
+			}
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
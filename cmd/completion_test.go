@@ -0,0 +1,138 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: completion_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:58:15
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:58:15
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+func TestWriteCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		var buf bytes.Buffer
+		if err := writeCompletion(&buf, shell); err != nil {
+			t.Errorf("%s: unexpected error: %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), "see") {
+			t.Errorf("%s: script does not mention the command", shell)
+		}
+	}
+	if err := writeCompletion(&bytes.Buffer{}, "tcsh"); err == nil {
+		t.Error("expected error for unsupported shell")
+	}
+}
+
+func TestCompletionInstallPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "cfg"))
+
+	tests := map[string]string{
+		"bash": filepath.Join(home, ".local", "share", "bash-completion", "completions", "see"),
+		"zsh":  filepath.Join(home, ".zfunc", "_see"),
+		"fish": filepath.Join(home, "cfg", "fish", "completions", "see.fish"),
+	}
+	for shell, want := range tests {
+		if got, err := completionInstallPath(shell); err != nil || got != want {
+			t.Errorf("%s: expected %s, got %s (%v)", shell, want, got, err)
+		}
+	}
+	if _, err := completionInstallPath("powershell"); err == nil {
+		t.Error("expected error for powershell")
+	}
+}
+
+func TestCompleteTagIDs(t *testing.T) {
+	withTestTags(t, seesdk.Tag{ID: 1, Name: "launch"}, seesdk.Tag{ID: 2, Name: "promo"})
+	withTestConfig(t, "")
+
+	got, _ := completeTagIDs(&cobra.Command{}, nil, "1,")
+	if want := []string{"1,1\tlaunch", "1,2\tpromo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestCompleteSlugs(t *testing.T) {
+	withTestTags(t)
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	cmd := &cobra.Command{}
+	ledgerCreated(cmd, ledgerRecord{Kind: kindShortURL, Domain: "s.ee", Slug: "a", Target: "https://a.example"})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindShortURL, Domain: "go.example", Slug: "b", Target: "https://b.example"})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindText, Domain: "s.ee", Slug: "c", Title: "notes"})
+
+	c := &cobra.Command{}
+	c.Flags().String("domain", "", "")
+	c.Flags().Set("domain", "s.ee")
+	got, _ := completeSlugs(kindShortURL)(c, nil, "")
+	if want := []string{"a\thttps://a.example"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	got, _ = completeSlugs(kindText)(c, nil, "")
+	if want := []string{"c\tnotes"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, _ := completeSlugs(kindShortURL)(c, []string{"a"}, ""); got != nil {
+		t.Errorf("expected no completion after the slug, got %v", got)
+	}
+}
+
+func TestCompleteDeleteKeys(t *testing.T) {
+	withTestTags(t)
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	cmd := &cobra.Command{}
+	ledgerCreated(cmd, ledgerRecord{Kind: kindFile, Filename: "a.png", DeleteKey: "ka"})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindFile, Filename: "b.png", DeleteKey: "kb"})
+
+	got, _ := completeDeleteKeys(&cobra.Command{}, []string{"ka"}, "")
+	if want := []string{"kb\tb.png"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestCompletionNeverCallsAPI(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	withTestConfig(t, "")
+	marker := filepath.Join(t.TempDir(), "ran")
+	t.Setenv("SEE_API_KEY_CMD", "touch "+marker+"; echo k")
+
+	// With nothing cached there is nothing to offer, and no key is looked up.
+	cmd := &cobra.Command{}
+	if got, _ := completeDomains(kindShortURL)(cmd, nil, ""); len(got) != 0 {
+		t.Errorf("expected no domains, got %v", got)
+	}
+	if got, _ := completeTags(cmd, nil, ""); len(got) != 0 {
+		t.Errorf("expected no tags, got %v", got)
+	}
+	if got, _ := completeTagIDs(cmd, nil, ""); len(got) != 0 {
+		t.Errorf("expected no tag IDs, got %v", got)
+	}
+	if got, _ := completeDeleteKeys(cmd, nil, ""); len(got) != 0 {
+		t.Errorf("expected no delete keys, got %v", got)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Error("expected api_key_cmd not to run")
+	}
+}
@@ -0,0 +1,618 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: config.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:24:25
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:04:38
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultProfileName is used when no profile is selected by flag, env or config
+	defaultProfileName = "default"
+
+	// settingAnnotation marks a flag as the command-line source of a setting
+	settingAnnotation = "see_setting"
+)
+
+// Sources a resolved setting value can come from, in order of precedence.
+// The API key may also come from api_key_cmd or the credentials file, see
+// sourceCommand and sourceCredentials.
+const (
+	sourceFlag    = "flag"
+	sourceEnv     = "env"
+	sourceProfile = "profile"
+	sourceDefault = "default"
+)
+
+// setting describes a value that can be given by flag, environment variable
+// or config profile.
+type setting struct {
+	key    string
+	env    string
+	def    string
+	secret bool
+	// parse validates a raw value and returns its normalized form
+	parse func(string) (string, error)
+}
+
+// settingDefs lists every setting the CLI knows about.
+var settingDefs = []setting{
+	{key: "api_key", env: "SEE_API_KEY", secret: true},
+	{key: "api_key_cmd", env: "SEE_API_KEY_CMD"},
+	{key: "base_url", env: "SEE_BASE_URL", def: seesdk.DefaultBaseURL},
+	{key: "timeout", env: "SEE_TIMEOUT", def: seesdk.DefaultTimeout.String(), parse: parseDurationSetting},
+	{key: "domain", env: "SEE_DOMAIN", def: "s.ee"},
+	{key: "file_private", env: "SEE_FILE_PRIVATE", def: "0", parse: parseBoolSetting},
+	{key: "cache_ttl", env: "SEE_CACHE_TTL", def: defaultCacheTTL.String(), parse: parseDurationSetting},
+	{key: "retries", env: "SEE_RETRIES", def: strconv.Itoa(defaultRetries), parse: parseCountSetting},
+	{key: "rate", env: "SEE_RATE", def: "0", parse: parseRateSetting},
+	{key: "retry_max_wait", env: "SEE_RETRY_MAX_WAIT", def: defaultRetryMaxWait.String(), parse: parseDurationSetting},
+}
+
+// settingValue is the effective value of a setting and where it came from.
+type settingValue struct {
+	Value  string
+	Source string
+}
+
+// configFile is the on-disk layout of the config file.
+type configFile struct {
+	DefaultProfile string                       `yaml:"default_profile,omitempty"`
+	Profiles       map[string]map[string]string `yaml:"profiles,omitempty"`
+}
+
+var (
+	// settings holds the values resolved for the current invocation
+	settings = map[string]settingValue{}
+
+	// activeProfile is the name of the profile used for the current invocation
+	activeProfile string
+
+	// unknownProfile is set when the selected profile was named explicitly
+	// but is not in the config file; it may still exist in the credentials
+	unknownProfile bool
+)
+
+// configPath returns the location of the config file. It honours --config,
+// then SEE_CONFIG, then $XDG_CONFIG_HOME/see/config.yaml and finally
+// ~/.config/see/config.yaml.
+func configPath() (string, error) {
+	if rootOpts.configPath != "" {
+		return rootOpts.configPath, nil
+	}
+	if p := os.Getenv("SEE_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// configDir returns the directory holding the CLI's config files.
+func configDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "see"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "see"), nil
+}
+
+// loadConfig reads the config file at path. A missing file yields an empty config.
+func loadConfig(path string) (*configFile, error) {
+	cfg := &configFile{}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// profileName picks the profile to use: --profile, then SEE_PROFILE, then
+// default_profile from the config file, then "default". It also reports
+// whether the profile was asked for explicitly.
+func profileName(cfg *configFile) (string, bool) {
+	if rootOpts.profile != "" {
+		return rootOpts.profile, true
+	}
+	if name := os.Getenv("SEE_PROFILE"); name != "" {
+		return name, true
+	}
+	if cfg.DefaultProfile != "" {
+		return cfg.DefaultProfile, false
+	}
+	return defaultProfileName, false
+}
+
+// selectProfile returns the selected profile, its settings and whether it
+// was asked for explicitly but is missing from the config file.
+func selectProfile(cfg *configFile) (string, map[string]string, bool) {
+	name, explicit := profileName(cfg)
+	profile, ok := cfg.Profiles[name]
+	return name, profile, explicit && !ok
+}
+
+// bindSetting marks the named flag as the command-line source of a setting,
+// so that it picks up the env or profile value when not given explicitly.
+func bindSetting(flags *pflag.FlagSet, flag, key string) {
+	_ = flags.SetAnnotation(flag, settingAnnotation, []string{key})
+}
+
+// resolveSettings computes every setting with the precedence
+// flag > env > profile > built-in default and writes the result back into
+// the bound flags of cmd.
+func resolveSettings(cmd *cobra.Command) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+	name, profile, missing := selectProfile(cfg)
+
+	bound := map[string][]*pflag.Flag{}
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if keys := f.Annotations[settingAnnotation]; len(keys) > 0 {
+			bound[keys[0]] = append(bound[keys[0]], f)
+		}
+	})
+
+	resolved := make(map[string]settingValue, len(settingDefs))
+	for _, s := range settingDefs {
+		changed := changedFlag(bound[s.key])
+		v := settingValue{Value: s.def, Source: sourceDefault}
+		if changed != nil {
+			v = settingValue{Value: changed.Value.String(), Source: sourceFlag}
+		} else if env := os.Getenv(s.env); s.env != "" && env != "" {
+			v = settingValue{Value: env, Source: sourceEnv}
+		} else if pv, ok := profile[s.key]; ok {
+			v = settingValue{Value: pv, Source: sourceProfile}
+		}
+
+		if s.parse != nil && v.Value != "" {
+			norm, err := s.parse(v.Value)
+			if err != nil {
+				return fmt.Errorf("invalid %s (from %s): %w", s.key, v.Source, err)
+			}
+			v.Value = norm
+		}
+		resolved[s.key] = v
+
+		if changed == nil {
+			for _, f := range bound[s.key] {
+				if err := f.Value.Set(v.Value); err != nil {
+					return fmt.Errorf("invalid %s (from %s): %w", s.key, v.Source, err)
+				}
+			}
+		}
+	}
+
+	settings = resolved
+	activeProfile = name
+	unknownProfile = missing
+	return nil
+}
+
+// changedFlag returns the first of flags that was set on the command line.
+func changedFlag(flags []*pflag.Flag) *pflag.Flag {
+	for _, f := range flags {
+		if f.Changed {
+			return f
+		}
+	}
+	return nil
+}
+
+// parseDurationSetting accepts a Go duration ("45s", "2m") or a plain number
+// of seconds, which is what SEE_TIMEOUT has always taken.
+func parseDurationSetting(s string) (string, error) {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return (time.Duration(n) * time.Second).String(), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return "", fmt.Errorf("expected a duration such as 30s or a number of seconds")
+	}
+	return d.String(), nil
+}
+
+// parseCountSetting accepts a non-negative integer.
+func parseCountSetting(s string) (string, error) {
+	n, err := strconv.ParseUint(s, 10, 31)
+	if err != nil {
+		return "", fmt.Errorf("expected a number such as 0 or 3")
+	}
+	return strconv.FormatUint(n, 10), nil
+}
+
+// parseBoolSetting accepts the usual boolean spellings and normalizes them
+// to "1" or "0", matching the integer flags such as --is-private.
+func parseBoolSetting(s string) (string, error) {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return "", fmt.Errorf("expected true/false or 1/0")
+	}
+	if b {
+		return "1", nil
+	}
+	return "0", nil
+}
+
+// saveConfig writes cfg to path, creating the directory if needed. The file
+// may hold API keys, so it is only readable by the owner.
+func saveConfig(path string, cfg *configFile) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes(), 0600)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// into place, so readers never see a partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// lookupSetting returns the definition of the named setting.
+func lookupSetting(key string) (setting, error) {
+	for _, s := range settingDefs {
+		if s.key == key {
+			return s, nil
+		}
+	}
+	keys := make([]string, 0, len(settingDefs))
+	for _, s := range settingDefs {
+		keys = append(keys, s.key)
+	}
+	return setting{}, fmt.Errorf("unknown setting %q (valid settings: %s)", key, strings.Join(keys, ", "))
+}
+
+// validateConfig checks that every profile only holds known settings with
+// valid values.
+func validateConfig(cfg *configFile) error {
+	if cfg.DefaultProfile != "" {
+		if _, ok := cfg.Profiles[cfg.DefaultProfile]; !ok {
+			return fmt.Errorf("default_profile %q has no matching profile", cfg.DefaultProfile)
+		}
+	}
+	for name, profile := range cfg.Profiles {
+		for key, value := range profile {
+			s, err := lookupSetting(key)
+			if err != nil {
+				return fmt.Errorf("profile %q: %w", name, err)
+			}
+			if s.parse != nil {
+				if _, err := s.parse(value); err != nil {
+					return fmt.Errorf("profile %q: invalid %s: %w", name, key, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// maskSecret hides all but the last four characters of a secret value.
+func maskSecret(v string) string {
+	if len(v) <= 4 {
+		return strings.Repeat("*", len(v))
+	}
+	return strings.Repeat("*", 8) + v[len(v)-4:]
+}
+
+// editorCommand returns the user's preferred editor, split into program and
+// arguments.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// defaultProfileKey is the top-level config key selecting the default
+// profile; it is accepted by config get/set/unset next to the profile settings.
+const defaultProfileKey = "default_profile"
+
+var configCmd = &cobra.Command{
+	Use:         "config",
+	Short:       "Manage CLI settings and profiles",
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the config file location",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configPath()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), path)
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] == defaultProfileKey {
+			if err := resolveSettings(cmd); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), activeProfile)
+			return nil
+		}
+		if _, err := lookupSetting(args[0]); err != nil {
+			return err
+		}
+		if err := resolveSettings(cmd); err != nil {
+			return err
+		}
+		// Only the key itself may need api_key_cmd or a passphrase.
+		if args[0] == "api_key" {
+			if err := resolveAPIKey(); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), settings[args[0]].Value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Store a setting in the selected profile",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateConfig(func(cfg *configFile, profile string) error {
+			key, value := args[0], args[1]
+			if key == defaultProfileKey {
+				if _, ok := cfg.Profiles[value]; !ok {
+					return fmt.Errorf("profile %q not found in config", value)
+				}
+				cfg.DefaultProfile = value
+				return nil
+			}
+
+			s, err := lookupSetting(key)
+			if err != nil {
+				return err
+			}
+			if s.parse != nil {
+				if _, err := s.parse(value); err != nil {
+					return fmt.Errorf("invalid %s: %w", key, err)
+				}
+			}
+			if cfg.Profiles == nil {
+				cfg.Profiles = map[string]map[string]string{}
+			}
+			if cfg.Profiles[profile] == nil {
+				cfg.Profiles[profile] = map[string]string{}
+			}
+			cfg.Profiles[profile][key] = value
+			return nil
+		})
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting from the selected profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateConfig(func(cfg *configFile, profile string) error {
+			key := args[0]
+			if key == defaultProfileKey {
+				cfg.DefaultProfile = ""
+				return nil
+			}
+			if _, err := lookupSetting(key); err != nil {
+				return err
+			}
+			delete(cfg.Profiles[profile], key)
+			if len(cfg.Profiles[profile]) == 0 {
+				delete(cfg.Profiles, profile)
+			}
+			return nil
+		})
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show every setting with its effective value and source",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveSettings(cmd); err != nil {
+			return err
+		}
+		if err := resolveAPIKey(); err != nil {
+			return err
+		}
+
+		type entry struct {
+			Key    string `json:"key"`
+			Value  string `json:"value"`
+			Source string `json:"source"`
+		}
+		entries := make([]entry, 0, len(settingDefs))
+		for _, s := range settingDefs {
+			v := settings[s.key]
+			if s.secret && v.Value != "" {
+				v.Value = maskSecret(v.Value)
+			}
+			entries = append(entries, entry{Key: s.key, Value: v.Value, Source: v.Source})
+		}
+
+		return render(cmd, result{
+			data:    entries,
+			columns: []string{"key", "value", "source"},
+			text: func(out io.Writer) error {
+				w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+				fmt.Fprintf(w, "# profile: %s\n", activeProfile)
+				fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+				for _, e := range entries {
+					fmt.Fprintf(w, "%s\t%s\t%s\n", e.Key, e.Value, e.Source)
+				}
+				return w.Flush()
+			},
+		})
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in $EDITOR",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configPath()
+		if err != nil {
+			return err
+		}
+		original, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		// Edit a scratch copy so that a broken file never replaces a working one.
+		tmp, err := os.CreateTemp("", "see-config-*.yaml")
+		if err != nil {
+			return err
+		}
+		tmpPath := tmp.Name()
+		_, err = tmp.Write(original)
+		if cerr := tmp.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(tmpPath)
+			return err
+		}
+
+		editor := editorCommand()
+		c := exec.Command(editor[0], append(editor[1:], tmpPath)...)
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := c.Run(); err != nil {
+			os.Remove(tmpPath)
+			return fmt.Errorf("editor failed: %w", err)
+		}
+
+		edited, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return err
+		}
+		cfg := &configFile{}
+		err = yaml.Unmarshal(edited, cfg)
+		if err == nil {
+			err = validateConfig(cfg)
+		}
+		if err != nil {
+			return fmt.Errorf("config not saved: %w (your edits are kept in %s)", err, tmpPath)
+		}
+		os.Remove(tmpPath)
+
+		if bytes.Equal(edited, original) {
+			fmt.Fprintln(cmd.ErrOrStderr(), "No changes made")
+			return nil
+		}
+		return writeFileAtomic(path, edited, 0600)
+	},
+}
+
+// updateConfig loads the config file, applies fn to it with the name of the
+// selected profile and saves the result.
+func updateConfig(fn func(cfg *configFile, profile string) error) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	profile, _ := profileName(cfg)
+	if err := fn(cfg, profile); err != nil {
+		return err
+	}
+	return saveConfig(path, cfg)
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configPathCmd)
+}
@@ -0,0 +1,242 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: config_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:24:25
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:30:04
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const testConfig = `default_profile: work
+profiles:
+  work:
+    api_key: work-key
+    base_url: https://work.example/api/v1
+    timeout: 45
+    domain: work.link
+    file_private: true
+  personal:
+    api_key: personal-key
+`
+
+type settingsTestOpts struct {
+	apiKey  string
+	timeout time.Duration
+	domain  string
+	private int
+}
+
+// newSettingsTestCmd returns a command whose flags are bound to settings the
+// same way the real commands bind them.
+func newSettingsTestCmd(t *testing.T, args ...string) (*cobra.Command, *settingsTestOpts) {
+	t.Helper()
+	opts := &settingsTestOpts{}
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringVar(&opts.apiKey, "api-key", "", "")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 0, "")
+	cmd.Flags().StringVar(&opts.domain, "domain", "s.ee", "")
+	cmd.Flags().IntVar(&opts.private, "is-private", 0, "")
+	bindSetting(cmd.Flags(), "api-key", "api_key")
+	bindSetting(cmd.Flags(), "timeout", "timeout")
+	bindSetting(cmd.Flags(), "domain", "domain")
+	bindSetting(cmd.Flags(), "is-private", "file_private")
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	return cmd, opts
+}
+
+// withTestConfig points the CLI at a temporary config file and clears the
+// environment variables that would otherwise leak into the test.
+func withTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv("SEE_CONFIG", path)
+	for _, s := range settingDefs {
+		t.Setenv(s.env, "")
+	}
+	t.Setenv("SEE_PROFILE", "")
+	rootOpts.profile = ""
+	rootOpts.configPath = ""
+	return path
+}
+
+func TestResolveSettings_Profile(t *testing.T) {
+	withTestConfig(t, testConfig)
+	cmd, opts := newSettingsTestCmd(t)
+
+	if err := resolveSettings(cmd); err != nil {
+		t.Fatalf("resolveSettings failed: %v", err)
+	}
+	if activeProfile != "work" {
+		t.Errorf("expected profile 'work', got %q", activeProfile)
+	}
+	if opts.apiKey != "work-key" {
+		t.Errorf("expected api key from profile, got %q", opts.apiKey)
+	}
+	if opts.timeout != 45*time.Second {
+		t.Errorf("expected timeout 45s, got %v", opts.timeout)
+	}
+	if opts.domain != "work.link" {
+		t.Errorf("expected domain from profile, got %q", opts.domain)
+	}
+	if opts.private != 1 {
+		t.Errorf("expected file_private from profile, got %d", opts.private)
+	}
+	if got := settings["base_url"]; got.Source != sourceProfile {
+		t.Errorf("expected base_url from profile, got %+v", got)
+	}
+}
+
+func TestResolveSettings_Precedence(t *testing.T) {
+	withTestConfig(t, testConfig)
+	t.Setenv("SEE_API_KEY", "env-key")
+	t.Setenv("SEE_DOMAIN", "env.link")
+	cmd, opts := newSettingsTestCmd(t, "--domain", "flag.link")
+
+	if err := resolveSettings(cmd); err != nil {
+		t.Fatalf("resolveSettings failed: %v", err)
+	}
+	if opts.apiKey != "env-key" || settings["api_key"].Source != sourceEnv {
+		t.Errorf("expected env to beat profile, got %q from %s", opts.apiKey, settings["api_key"].Source)
+	}
+	if opts.domain != "flag.link" || settings["domain"].Source != sourceFlag {
+		t.Errorf("expected flag to beat env, got %q from %s", opts.domain, settings["domain"].Source)
+	}
+	if settings["file_private"].Source != sourceProfile {
+		t.Errorf("expected file_private from profile, got %s", settings["file_private"].Source)
+	}
+}
+
+func TestResolveSettings_SelectProfile(t *testing.T) {
+	withTestConfig(t, testConfig)
+	t.Setenv("SEE_PROFILE", "personal")
+	cmd, opts := newSettingsTestCmd(t)
+
+	if err := resolveSettings(cmd); err != nil {
+		t.Fatalf("resolveSettings failed: %v", err)
+	}
+	if opts.apiKey != "personal-key" {
+		t.Errorf("expected personal api key, got %q", opts.apiKey)
+	}
+	if opts.domain != "s.ee" || settings["domain"].Source != sourceDefault {
+		t.Errorf("expected built-in default domain, got %q from %s", opts.domain, settings["domain"].Source)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	rootOpts.profile = "missing"
+	defer func() { rootOpts.profile = "" }()
+	if err := resolveSettings(cmd); err != nil {
+		t.Fatalf("resolveSettings failed: %v", err)
+	}
+	if err := resolveAPIKey(); err == nil {
+		t.Error("expected error for unknown profile, got nil")
+	}
+}
+
+func TestResolveSettings_NoConfig(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("SEE_CONFIG", filepath.Join(t.TempDir(), "absent.yaml"))
+	cmd, opts := newSettingsTestCmd(t)
+
+	if err := resolveSettings(cmd); err != nil {
+		t.Fatalf("resolveSettings failed: %v", err)
+	}
+	if opts.apiKey != "" {
+		t.Errorf("expected no api key, got %q", opts.apiKey)
+	}
+	if activeProfile != defaultProfileName {
+		t.Errorf("expected profile %q, got %q", defaultProfileName, activeProfile)
+	}
+}
+
+func TestResolveSettings_InvalidValue(t *testing.T) {
+	withTestConfig(t, testConfig)
+	t.Setenv("SEE_TIMEOUT", "soon")
+	cmd, _ := newSettingsTestCmd(t)
+
+	if err := resolveSettings(cmd); err == nil {
+		t.Error("expected error for invalid timeout, got nil")
+	}
+}
+
+func TestConfigGet_APIKeyOnlyWhenAsked(t *testing.T) {
+	withTestConfig(t, "")
+	marker := filepath.Join(t.TempDir(), "ran")
+	t.Setenv("SEE_API_KEY_CMD", "touch "+marker+"; echo cmd-key")
+	savedOpts, savedSettings := rootOpts, settings
+	defer func() { rootOpts, settings = savedOpts, savedSettings }()
+	var out bytes.Buffer
+	configGetCmd.SetOut(&out)
+	defer configGetCmd.SetOut(nil)
+
+	if err := configGetCmd.RunE(configGetCmd, []string{"timeout"}); err != nil {
+		t.Fatalf("config get timeout failed: %v", err)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Error("expected api_key_cmd not to run for another key")
+	}
+
+	out.Reset()
+	if err := configGetCmd.RunE(configGetCmd, []string{"api_key"}); err != nil {
+		t.Fatalf("config get api_key failed: %v", err)
+	}
+	if out.String() != "cmd-key\n" {
+		t.Errorf("expected the key from api_key_cmd, got %q", out.String())
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	valid := &configFile{
+		DefaultProfile: "work",
+		Profiles: map[string]map[string]string{
+			"work": {"api_key": "k", "timeout": "10s", "file_private": "true"},
+		},
+	}
+	if err := validateConfig(valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  *configFile
+	}{
+		{"unknown key", &configFile{Profiles: map[string]map[string]string{"p": {"apikey": "k"}}}},
+		{"bad value", &configFile{Profiles: map[string]map[string]string{"p": {"timeout": "soon"}}}},
+		{"missing default", &configFile{DefaultProfile: "nope"}},
+	}
+	for _, tt := range tests {
+		if err := validateConfig(tt.cfg); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	if got := maskSecret("abcdefghij"); got != "********ghij" {
+		t.Errorf("unexpected mask: %q", got)
+	}
+	if got := maskSecret("abc"); got != "***" {
+		t.Errorf("unexpected mask for short secret: %q", got)
+	}
+}
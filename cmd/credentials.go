@@ -0,0 +1,413 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: credentials.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:29:18
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:29:18
+//
+
+package cmd
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+// sourceCredentials marks an API key that was loaded from the credentials
+// file written by "see login".
+const sourceCredentials = "credentials"
+
+// Key derivation modes for stored credentials.
+const (
+	kdfMachine    = "machine"
+	kdfPassphrase = "passphrase"
+)
+
+// credentialsFile is the on-disk layout of the credentials file.
+type credentialsFile struct {
+	Version  int                         `json:"version"`
+	Profiles map[string]storedCredential `json:"profiles"`
+}
+
+// storedCredential is an API key sealed with AES-256-GCM under a key derived
+// with scrypt from either the machine identity or a passphrase.
+type storedCredential struct {
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+var loginOpts struct {
+	passphrase bool
+}
+
+// credentialsPath returns the location of the credentials file.
+func credentialsPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "credentials"), nil
+}
+
+// loadCredentials reads the credentials file. A missing file yields an empty set.
+func loadCredentials() (*credentialsFile, error) {
+	creds := &credentialsFile{Version: 1, Profiles: map[string]storedCredential{}}
+	path, err := credentialsPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return creds, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, creds); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", path, err)
+	}
+	if creds.Profiles == nil {
+		creds.Profiles = map[string]storedCredential{}
+	}
+	return creds, nil
+}
+
+// saveCredentials writes the credentials file, or removes it once it no
+// longer holds any profile.
+func saveCredentials(creds *credentialsFile) error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+	if len(creds.Profiles) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	b, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b, 0600)
+}
+
+// machineSecret returns a value that is stable for this user on this machine.
+// It is not a secret in the strict sense, but it keeps the credentials file
+// useless when copied elsewhere.
+func machineSecret() []byte {
+	var parts []string
+	for _, p := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		if b, err := os.ReadFile(p); err == nil {
+			parts = append(parts, strings.TrimSpace(string(b)))
+			break
+		}
+	}
+	if host, err := os.Hostname(); err == nil {
+		parts = append(parts, host)
+	}
+	if u, err := user.Current(); err == nil {
+		parts = append(parts, u.Uid, u.Username)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		parts = append(parts, home)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return sum[:]
+}
+
+// deriveCredentialKey stretches the machine secret or passphrase into an
+// AES-256 key.
+func deriveCredentialKey(kdf string, salt []byte, passphrase string) ([]byte, error) {
+	var secret []byte
+	switch kdf {
+	case kdfMachine:
+		secret = machineSecret()
+	case kdfPassphrase:
+		secret = []byte(passphrase)
+	default:
+		return nil, fmt.Errorf("unsupported key derivation %q", kdf)
+	}
+	return scrypt.Key(secret, salt, 1<<15, 8, 1, 32)
+}
+
+// sealCredential encrypts an API key for storage.
+func sealCredential(apiKey, kdf, passphrase string) (storedCredential, error) {
+	c := storedCredential{KDF: kdf, Salt: make([]byte, 16)}
+	if _, err := rand.Read(c.Salt); err != nil {
+		return c, err
+	}
+	key, err := deriveCredentialKey(kdf, c.Salt, passphrase)
+	if err != nil {
+		return c, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return c, err
+	}
+	c.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(c.Nonce); err != nil {
+		return c, err
+	}
+	c.Ciphertext = gcm.Seal(nil, c.Nonce, []byte(apiKey), []byte(kdf))
+	return c, nil
+}
+
+// openCredential decrypts a stored API key.
+func openCredential(c storedCredential, passphrase string) (string, error) {
+	key, err := deriveCredentialKey(c.KDF, c.Salt, passphrase)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	plain, err := gcm.Open(nil, c.Nonce, c.Ciphertext, []byte(c.KDF))
+	if err != nil {
+		if c.KDF == kdfPassphrase {
+			return "", errors.New("cannot decrypt stored credentials: wrong passphrase")
+		}
+		return "", errors.New("cannot decrypt stored credentials on this machine: run 'see login' again")
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// storedAPIKey returns the API key saved by "see login" for the profile, or
+// "" when there is none. Passphrase-protected keys read the passphrase from
+// SEE_CREDENTIALS_PASSPHRASE or prompt for it on a terminal.
+func storedAPIKey(profile string) (string, error) {
+	creds, err := loadCredentials()
+	if err != nil {
+		return "", err
+	}
+	c, ok := creds.Profiles[profile]
+	if !ok {
+		return "", nil
+	}
+
+	var passphrase string
+	if c.KDF == kdfPassphrase {
+		passphrase = os.Getenv("SEE_CREDENTIALS_PASSPHRASE")
+		if passphrase == "" {
+			// Never read a passphrase from piped stdin, it carries command input.
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				return "", errors.New("stored credentials are passphrase protected: set SEE_CREDENTIALS_PASSPHRASE")
+			}
+			if passphrase, err = promptSecret(os.Stdin, os.Stderr, "Credentials passphrase: "); err != nil {
+				return "", err
+			}
+		}
+	}
+	return openCredential(c, passphrase)
+}
+
+// useStoredAPIKey falls back to the key saved by "see login" when neither
+// flag, env nor profile provided one. It also rejects a profile that was
+// named explicitly but exists neither in the config nor in the credentials.
+func useStoredAPIKey() error {
+	if settings["api_key"].Value != "" && !unknownProfile {
+		return nil
+	}
+	key, err := storedAPIKey(activeProfile)
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		if unknownProfile {
+			return fmt.Errorf("profile %q not found in config or credentials", activeProfile)
+		}
+		return nil
+	}
+	if settings["api_key"].Value != "" {
+		return nil
+	}
+	rootOpts.apiKey = key
+	settings["api_key"] = settingValue{Value: key, Source: sourceCredentials}
+	return nil
+}
+
+// promptSecret asks for a single value, as secretPrompter.read does.
+func promptSecret(in io.Reader, out io.Writer, prompt string) (string, error) {
+	return newSecretPrompter(in, out).read(prompt)
+}
+
+// secretPrompter reads secrets one after the other from the same input. It
+// reads piped input through one buffer, so that a line read ahead for one
+// secret is still there for the next.
+type secretPrompter struct {
+	in  io.Reader
+	out io.Writer
+	buf *bufio.Reader
+}
+
+func newSecretPrompter(in io.Reader, out io.Writer) *secretPrompter {
+	return &secretPrompter{in: in, out: out}
+}
+
+// terminal reports whether the input is a terminal.
+func (p *secretPrompter) terminal() bool {
+	f, ok := p.in.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// read asks for a value without echoing it when the input is a terminal,
+// and reads a single line otherwise so that secrets can be piped in.
+func (p *secretPrompter) read(prompt string) (string, error) {
+	if p.terminal() {
+		fd := int(p.in.(*os.File).Fd())
+		fmt.Fprint(p.out, prompt)
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(p.out)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+
+	if p.buf == nil {
+		p.buf = bufio.NewReader(p.in)
+	}
+	line, err := p.buf.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", errors.New("no input: run on a terminal or pipe the value via stdin")
+	}
+	return line, nil
+}
+
+var loginCmd = &cobra.Command{
+	Use:         "login",
+	Short:       "Verify an API key and store it for the selected profile",
+	Long:        "Prompt for an API key without echoing it, verify it against the API and store it encrypted in the credentials file. Commands fall back to the stored key when no --api-key or SEE_API_KEY is given.",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveSettings(cmd); err != nil {
+			return err
+		}
+
+		prompter := newSecretPrompter(cmd.InOrStdin(), cmd.ErrOrStderr())
+		apiKey, err := prompter.read("API key: ")
+		if err != nil {
+			return err
+		}
+
+		client := seesdk.NewClient(seesdk.Config{
+			BaseURL: rootOpts.baseURL,
+			APIKey:  apiKey,
+			Timeout: rootOpts.timeout,
+		})
+		if _, err := client.GetDomains(); err != nil {
+			return fmt.Errorf("API key verification failed: %w", err)
+		}
+
+		kdf, passphrase := kdfMachine, ""
+		if loginOpts.passphrase {
+			kdf = kdfPassphrase
+			passphrase = os.Getenv("SEE_CREDENTIALS_PASSPHRASE")
+			if passphrase == "" {
+				if passphrase, err = prompter.read("New passphrase: "); err != nil {
+					return err
+				}
+				// A typo would lock the key away, so ask twice when typed.
+				if prompter.terminal() {
+					confirm, err := prompter.read("Confirm passphrase: ")
+					if err != nil {
+						return err
+					}
+					if confirm != passphrase {
+						return errors.New("passphrases do not match")
+					}
+				}
+			}
+		}
+		sealed, err := sealCredential(apiKey, kdf, passphrase)
+		if err != nil {
+			return err
+		}
+
+		creds, err := loadCredentials()
+		if err != nil {
+			return err
+		}
+		creds.Profiles[activeProfile] = sealed
+		if err := saveCredentials(creds); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Logged in (profile %q)\n", activeProfile)
+		return nil
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:         "logout",
+	Short:       "Remove the stored API key of the selected profile",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configPath()
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig(path)
+		if err != nil {
+			return err
+		}
+		profile, _ := profileName(cfg)
+
+		creds, err := loadCredentials()
+		if err != nil {
+			return err
+		}
+		if _, ok := creds.Profiles[profile]; !ok {
+			fmt.Fprintf(cmd.OutOrStdout(), "Not logged in (profile %q)\n", profile)
+			return nil
+		}
+		delete(creds.Profiles, profile)
+		if err := saveCredentials(creds); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Logged out (profile %q)\n", profile)
+		return nil
+	},
+}
+
+func init() {
+	loginCmd.Flags().BoolVar(&loginOpts.passphrase, "passphrase", false, "Protect the stored key with a passphrase instead of a machine-bound key")
+}
@@ -0,0 +1,125 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: credentials_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:29:18
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:29:18
+//
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSealCredential_RoundTrip(t *testing.T) {
+	for _, kdf := range []string{kdfMachine, kdfPassphrase} {
+		sealed, err := sealCredential("secret-key", kdf, "hunter2")
+		if err != nil {
+			t.Fatalf("%s: seal failed: %v", kdf, err)
+		}
+		got, err := openCredential(sealed, "hunter2")
+		if err != nil {
+			t.Fatalf("%s: open failed: %v", kdf, err)
+		}
+		if got != "secret-key" {
+			t.Errorf("%s: expected %q, got %q", kdf, "secret-key", got)
+		}
+	}
+
+	sealed, err := sealCredential("secret-key", kdfPassphrase, "hunter2")
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	if _, err := openCredential(sealed, "wrong"); err == nil {
+		t.Error("expected error for wrong passphrase, got nil")
+	}
+}
+
+func TestStoredAPIKey(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	key, err := storedAPIKey("default")
+	if err != nil || key != "" {
+		t.Fatalf("expected no stored key, got %q, %v", key, err)
+	}
+
+	sealed, err := sealCredential("stored-key", kdfMachine, "")
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	creds := &credentialsFile{Version: 1, Profiles: map[string]storedCredential{"default": sealed}}
+	if err := saveCredentials(creds); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	path, _ := credentialsPath()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected mode 0600, got %o", perm)
+	}
+
+	key, err = storedAPIKey("default")
+	if err != nil || key != "stored-key" {
+		t.Errorf("expected stored key, got %q, %v", key, err)
+	}
+
+	delete(creds.Profiles, "default")
+	if err := saveCredentials(creds); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected credentials file to be removed")
+	}
+}
+
+func TestLoginPipedPassphrase(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("SEE_CREDENTIALS_PASSPHRASE", "")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":200,"data":{"domains":["s.ee"]}}`)
+	}))
+	defer srv.Close()
+	prev := rootOpts.baseURL
+	rootOpts.baseURL = srv.URL
+	defer func() { rootOpts.baseURL = prev }()
+
+	// Both lines come from the same pipe.
+	loginOpts.passphrase = true
+	defer func() { loginOpts.passphrase = false }()
+	loginCmd.SetIn(strings.NewReader("piped-key\npiped-pass\n"))
+	loginCmd.SetOut(io.Discard)
+	loginCmd.SetErr(io.Discard)
+	defer func() {
+		loginCmd.SetIn(nil)
+		loginCmd.SetOut(nil)
+		loginCmd.SetErr(nil)
+	}()
+	if err := loginCmd.RunE(loginCmd, nil); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+
+	creds, err := loadCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := openCredential(creds.Profiles[activeProfile], "piped-pass")
+	if err != nil || key != "piped-key" {
+		t.Errorf("expected the key sealed with the piped passphrase, got %q, %v", key, err)
+	}
+}
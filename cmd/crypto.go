@@ -0,0 +1,301 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: crypto.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:13:50
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:16:07
+//
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// keySize is the size of the AES-256 keys of encrypted content.
+	keySize = 32
+
+	// textEnvelopePrefix starts the content of an encrypted paste. It names
+	// the format, so that a future format can be told apart.
+	textEnvelopePrefix = "see-e2e:v1:"
+
+	// fileMagic starts an encrypted file and names its format version.
+	fileMagic = "see-enc\x01"
+
+	// fileSaltSize is the size of the random salt after fileMagic, from
+	// which the key of each file is derived.
+	fileSaltSize = 16
+
+	// fileChunkSize is the plaintext size of each encrypted chunk but the
+	// last, which may be shorter.
+	fileChunkSize = 64 * 1024
+
+	// fileEncExt is appended to the names of encrypted uploads.
+	fileEncExt = ".enc"
+)
+
+// newKey returns a random key.
+func newKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// encodeKey renders key as a token that fits in a URL fragment.
+func encodeKey(key []byte) string {
+	return base64.RawURLEncoding.EncodeToString(key)
+}
+
+// parseKey reads a key given as a token or as a URL carrying it as its
+// fragment, as printed by the create commands.
+func parseKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if u, err := url.Parse(s); err == nil && u.Fragment != "" {
+		s = u.Fragment
+	}
+	key, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil || len(key) != keySize {
+		return nil, errors.New("invalid key: expected the token printed when the content was encrypted")
+	}
+	return key, nil
+}
+
+// sealText encrypts plaintext into a paste envelope: the prefix followed by
+// the base64url-encoded nonce and ciphertext. The prefix is authenticated
+// too.
+func sealText(key, plaintext []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, plaintext, []byte(textEnvelopePrefix))
+	return textEnvelopePrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// textEnvelope returns the envelope that the content of a paste consists
+// of, or "" if it is not encrypted. A plaintext paste that merely contains
+// an envelope, such as docs about this feature, is not taken for one.
+func textEnvelope(content []byte) string {
+	s := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(s, textEnvelopePrefix) {
+		return ""
+	}
+	return s
+}
+
+// openText decrypts a paste envelope made by sealText.
+func openText(key []byte, envelope string) ([]byte, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(envelope, textEnvelopePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted content: %w", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted content: too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(textEnvelopePrefix))
+	if err != nil {
+		return nil, errors.New("failed to decrypt: wrong key or modified content")
+	}
+	return plaintext, nil
+}
+
+// Encrypted files follow the STREAM construction used by age: a header of
+// fileMagic and a random salt, then the content in chunks of fileChunkSize,
+// each sealed with AES-256-GCM under a key derived from the user's key and
+// the salt. A chunk's nonce is its index and a flag marking the last chunk,
+// so chunks cannot be reordered, dropped or cut off at the end unnoticed,
+// and a single key can encrypt any number of files.
+
+// fileAEAD derives the cipher of a file from key and the file's salt.
+func fileAEAD(key, salt []byte) (cipher.AEAD, error) {
+	derived := make([]byte, keySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, salt, []byte("see-enc payload")), derived); err != nil {
+		return nil, err
+	}
+	return newGCM(derived)
+}
+
+// chunkNonce returns the nonce of chunk index.
+func chunkNonce(index uint64, last bool) []byte {
+	nonce := make([]byte, 12)
+	for i := 10; i >= 3 && index > 0; i-- {
+		nonce[i] = byte(index)
+		index >>= 8
+	}
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// encryptedSize returns the size of size bytes once encrypted.
+func encryptedSize(size int64) int64 {
+	chunks := max(1, (size+fileChunkSize-1)/fileChunkSize)
+	return int64(len(fileMagic)) + fileSaltSize + size + chunks*16
+}
+
+// encryptReader encrypts the content of r as it is read. It can rewind to
+// the start when r can, which lets retryStream resend an encrypted upload;
+// the content is then encrypted again with a new salt.
+type encryptReader struct {
+	r     io.Reader
+	br    *bufio.Reader
+	key   []byte
+	aead  cipher.AEAD
+	index uint64
+	out   []byte
+	done  bool
+}
+
+// newEncryptReader returns a reader of the content of r encrypted with key.
+func newEncryptReader(key []byte, r io.Reader) (*encryptReader, error) {
+	e := &encryptReader{r: r, key: key}
+	if err := e.reset(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// reset starts the stream over with a new salt.
+func (e *encryptReader) reset() error {
+	salt := make([]byte, fileSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aead, err := fileAEAD(e.key, salt)
+	if err != nil {
+		return err
+	}
+	e.br = bufio.NewReaderSize(e.r, fileChunkSize+1)
+	e.aead = aead
+	e.index = 0
+	e.out = append([]byte(fileMagic), salt...)
+	e.done = false
+	return nil
+}
+
+func (e *encryptReader) Read(b []byte) (int, error) {
+	for len(e.out) == 0 {
+		if e.done {
+			return 0, io.EOF
+		}
+		chunk := make([]byte, fileChunkSize)
+		n, err := io.ReadFull(e.br, chunk)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		// The chunk is the last one when nothing follows it.
+		last := n < fileChunkSize
+		if !last {
+			if _, err := e.br.Peek(1); err == io.EOF {
+				last = true
+			} else if err != nil {
+				return 0, err
+			}
+		}
+		e.out = e.aead.Seal(nil, chunkNonce(e.index, last), chunk[:n], nil)
+		e.index++
+		e.done = last
+	}
+	n := copy(b, e.out)
+	e.out = e.out[n:]
+	return n, nil
+}
+
+// Seek rewinds the stream; only Seek(0, io.SeekStart) is supported.
+func (e *encryptReader) Seek(offset int64, whence int) (int64, error) {
+	s, ok := e.r.(io.Seeker)
+	if !ok || offset != 0 || whence != io.SeekStart {
+		return 0, errors.New("seek not supported")
+	}
+	if _, err := s.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return 0, e.reset()
+}
+
+// decryptReader returns the content of an encrypted file read from r.
+type decryptReader struct {
+	br    *bufio.Reader
+	aead  cipher.AEAD
+	index uint64
+	out   []byte
+	done  bool
+}
+
+// newDecryptReader reads the header of an encrypted file from r.
+func newDecryptReader(key []byte, r io.Reader) (*decryptReader, error) {
+	header := make([]byte, len(fileMagic)+fileSaltSize)
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.HasPrefix(header, []byte(fileMagic)) {
+		return nil, errors.New("not a file encrypted by see")
+	}
+	aead, err := fileAEAD(key, header[len(fileMagic):])
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{br: bufio.NewReaderSize(r, fileChunkSize+17), aead: aead}, nil
+}
+
+func (d *decryptReader) Read(b []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		chunk := make([]byte, fileChunkSize+d.aead.Overhead())
+		n, err := io.ReadFull(d.br, chunk)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		last := n < len(chunk)
+		if !last {
+			if _, err := d.br.Peek(1); err == io.EOF {
+				last = true
+			} else if err != nil {
+				return 0, err
+			}
+		}
+		plain, err := d.aead.Open(nil, chunkNonce(d.index, last), chunk[:n], nil)
+		if err != nil {
+			if d.index == 0 {
+				return 0, errors.New("failed to decrypt: wrong key or modified content")
+			}
+			return 0, errors.New("failed to decrypt: the file is truncated or was modified")
+		}
+		d.out = plain
+		d.index++
+		d.done = last
+	}
+	n := copy(b, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
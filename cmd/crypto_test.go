@@ -0,0 +1,176 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: crypto_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:13:50
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:16:07
+//
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSealOpenText(t *testing.T) {
+	key, err := newKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope, err := sealText(key, []byte("secret notes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(envelope, textEnvelopePrefix) || strings.Contains(envelope, "secret") {
+		t.Fatalf("unexpected envelope %q", envelope)
+	}
+
+	// Only content that is an envelope counts, not text mentioning one.
+	if got := textEnvelope([]byte(envelope + "\n")); got != envelope {
+		t.Fatalf("expected the envelope, got %q", got)
+	}
+	if got := textEnvelope([]byte("Encrypted pastes look like " + envelope)); got != "" {
+		t.Errorf("expected plaintext mentioning an envelope to be plaintext, got %q", got)
+	}
+	plain, err := openText(key, envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plain, []byte("secret notes")) {
+		t.Errorf("expected the plaintext back, got %q", plain)
+	}
+
+	other, _ := newKey()
+	if _, err := openText(other, envelope); err == nil {
+		t.Error("expected error for the wrong key, got nil")
+	}
+	tampered := envelope[:len(envelope)-2] + "AA"
+	if tampered != envelope {
+		if _, err := openText(key, tampered); err == nil {
+			t.Error("expected error for modified content, got nil")
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	key, _ := newKey()
+	token := encodeKey(key)
+	for _, s := range []string{token, " " + token + "\n", "https://s.ee/abc#" + token} {
+		got, err := parseKey(s)
+		if err != nil {
+			t.Errorf("parseKey(%q) failed: %v", s, err)
+			continue
+		}
+		if !bytes.Equal(got, key) {
+			t.Errorf("parseKey(%q) returned another key", s)
+		}
+	}
+	for _, s := range []string{"", "short", "https://s.ee/abc", token + "AAAA"} {
+		if _, err := parseKey(s); err == nil {
+			t.Errorf("parseKey(%q): expected error, got nil", s)
+		}
+	}
+}
+
+func encryptTestBytes(t *testing.T, key, plain []byte) []byte {
+	t.Helper()
+	er, err := newEncryptReader(key, bytes.NewReader(plain))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := io.ReadAll(er)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enc
+}
+
+func decryptTestBytes(key, enc []byte) ([]byte, error) {
+	dr, err := newDecryptReader(key, bytes.NewReader(enc))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(dr)
+}
+
+func TestEncryptDecryptStream(t *testing.T) {
+	key, _ := newKey()
+	for _, size := range []int{0, 1, fileChunkSize - 1, fileChunkSize, fileChunkSize + 1, 3*fileChunkSize + 17} {
+		plain := bytes.Repeat([]byte{byte(size)}, size)
+		enc := encryptTestBytes(t, key, plain)
+		if int64(len(enc)) != encryptedSize(int64(size)) {
+			t.Errorf("size %d: expected %d encrypted bytes, got %d", size, encryptedSize(int64(size)), len(enc))
+		}
+		got, err := decryptTestBytes(key, enc)
+		if err != nil {
+			t.Errorf("size %d: decrypt failed: %v", size, err)
+			continue
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("size %d: plaintext differs", size)
+		}
+	}
+}
+
+func TestDecryptStreamRejectsTampering(t *testing.T) {
+	key, _ := newKey()
+	plain := bytes.Repeat([]byte("x"), 2*fileChunkSize+10)
+	enc := encryptTestBytes(t, key, plain)
+	header := len(fileMagic) + fileSaltSize
+
+	other, _ := newKey()
+	cases := map[string]struct {
+		key []byte
+		enc []byte
+	}{
+		"wrong key": {other, enc},
+		// Dropping the last chunk leaves a stream that ends in a chunk
+		// not sealed as the last one.
+		"truncated at chunk": {key, enc[:header+2*(fileChunkSize+16)]},
+		"truncated":          {key, enc[:len(enc)-5]},
+		"modified":           {key, append(append([]byte{}, enc[:header+100]...), append([]byte{enc[header+100] ^ 1}, enc[header+101:]...)...)},
+		"not encrypted":      {key, plain},
+	}
+	for name, c := range cases {
+		if _, err := decryptTestBytes(c.key, c.enc); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestEncryptReaderRewinds(t *testing.T) {
+	key, _ := newKey()
+	plain := bytes.Repeat([]byte("abc"), fileChunkSize)
+	er, err := newEncryptReader(key, bytes.NewReader(plain))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := make([]byte, 1000)
+	if _, err := io.ReadFull(er, first); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := er.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := io.ReadAll(er)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The rewound stream uses a new salt, so it must not repeat the first.
+	if bytes.Equal(enc[:len(first)], first) {
+		t.Error("expected a new salt after rewinding")
+	}
+	got, err := decryptTestBytes(key, enc)
+	if err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("expected the rewound stream to decrypt, got error %v", err)
+	}
+}
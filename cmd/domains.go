@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2025 S.EE Development Team
+// Copyright (c) 2025-2026 S.EE Development Team
 //
 // This source code is licensed under the MIT License,
 // which is located in the LICENSE file in the source tree's root directory.
@@ -9,13 +9,17 @@
 // File Created: 2025-12-22 22:29:22
 //
 // Modified By: S.EE Development Team <dev@s.ee>
-// Last Modified: 2025-12-23 11:07:58
+// Last Modified: 2026-10-18 08:52:34
 //
 
 package cmd
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -24,16 +28,154 @@ var domainsCmd = &cobra.Command{
 	Use:   "domains",
 	Short: "List available domains",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		resp, err := apiClient.GetDomains()
+		return listDomains(cmd, kindShortURL)
+	},
+}
+
+var textDomainsCmd = &cobra.Command{
+	Use:   "domains",
+	Short: "List available text domains",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listDomains(cmd, kindText)
+	},
+}
+
+// listDomains prints the domains of a kind of content, fetched from the API.
+func listDomains(cmd *cobra.Command, kind string) error {
+	domains, err := refreshCache(domainCacheName(kind), domainFetcher(kind))
+	if err != nil {
+		return err
+	}
+	return render(cmd, result{
+		data:    domains,
+		columns: []string{"domain"},
+		text: func(w io.Writer) error {
+			for _, d := range domains {
+				fmt.Fprintln(w, d)
+			}
+			return nil
+		},
+	})
+}
+
+// domainCacheName returns the cache entry of the domains of a kind.
+func domainCacheName(kind string) string {
+	return kind + "-domains"
+}
+
+// domainFetcher returns the API call listing the domains of a kind.
+func domainFetcher(kind string) func() ([]string, error) {
+	return func() ([]string, error) {
+		get := apiClient.GetDomains
+		switch kind {
+		case kindText:
+			get = apiClient.GetTextDomains
+		case kindFile:
+			get = apiClient.GetFileDomains
+		}
+		resp, err := get()
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp.Data.Domains)
+		return resp.Data.Domains, nil
+	}
+}
+
+// cachedDomains returns the domains of a kind from the cache, or from the API
+// when the cache is missing or stale.
+func cachedDomains(kind string) ([]string, bool, error) {
+	return cachedValue(domainCacheName(kind), domainFetcher(kind))
+}
+
+// checkDomain verifies that domain is available for a kind of content before
+// a request is sent, and suggests the closest match when it is not. A cached
+// list is refreshed once before the domain is rejected. When the domains
+// cannot be listed, as when offline, a stale cached list is used; without
+// any, the check is skipped and the server decides.
+func checkDomain(kind, domain string) error {
+	if domain == "" {
+		return nil
+	}
+	domains, cached, err := cachedDomains(kind)
+	if err != nil {
+		// Offline, a stale cached list still serves for validation.
+		if !readCache(domainCacheName(kind), math.MaxInt64, &domains) {
+			return nil
 		}
-		for _, d := range resp.Data.Domains {
-			fmt.Fprintln(cmd.OutOrStdout(), d)
+		cached = false
+	}
+	if !containsFold(domains, domain) && cached {
+		// The domain may have been added since the list was cached.
+		if fresh, err := refreshCache(domainCacheName(kind), domainFetcher(kind)); err == nil {
+			domains = fresh
 		}
+	}
+	if len(domains) == 0 || containsFold(domains, domain) {
 		return nil
-	},
+	}
+
+	what := map[string]string{kindShortURL: "short URLs", kindText: "texts", kindFile: "files"}[kind]
+	msg := fmt.Sprintf("domain %q is not available for %s", domain, what)
+	if s := closestMatch(domain, domains); s != "" {
+		msg += fmt.Sprintf("; did you mean %q?", s)
+	}
+	sorted := append([]string(nil), domains...)
+	sort.Strings(sorted)
+	return fmt.Errorf("%s (available: %s)", msg, strings.Join(sorted, ", "))
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// closestMatch returns the candidate with the smallest edit distance to s,
+// or "" when none is close enough to be a likely typo.
+func closestMatch(s string, candidates []string) string {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(s), strings.ToLower(c))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if bestDist < 0 || bestDist > max(2, len(s)/3) {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// completeDomains returns a completion function for the --domain flag of a
+// kind of content, served from the domain cache only.
+func completeDomains(kind string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completionCache[[]string](cmd, domainCacheName(kind)), cobra.ShellCompDirectiveNoFileComp
+	}
 }
@@ -0,0 +1,107 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: domains_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:52:34
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:52:34
+//
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// withTestDomains fills the domain cache of a kind. The API is unreachable
+// in tests, so the cache is all that checkDomain can use.
+func withTestDomains(t *testing.T, kind string, domains ...string) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	withTestTags(t)
+	withTestConfig(t, "")
+	if err := writeCache(domainCacheName(kind), domains); err != nil {
+		t.Fatalf("writeCache failed: %v", err)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"s.ee", "s.ee", 0},
+		{"s.ez", "s.ee", 1},
+		{"se.e", "s.ee", 2},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	domains := []string{"s.ee", "go.example.com", "links.example.org"}
+	if got := closestMatch("go.exmaple.com", domains); got != "go.example.com" {
+		t.Errorf("expected go.example.com, got %q", got)
+	}
+	if got := closestMatch("S.E", domains); got != "s.ee" {
+		t.Errorf("expected s.ee, got %q", got)
+	}
+	if got := closestMatch("unrelated.net", domains); got != "" {
+		t.Errorf("expected no suggestion, got %q", got)
+	}
+}
+
+func TestCheckDomain(t *testing.T) {
+	withTestDomains(t, kindShortURL, "s.ee", "go.example.com")
+
+	if err := checkDomain(kindShortURL, "go.example.com"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := checkDomain(kindShortURL, "S.EE"); err != nil {
+		t.Errorf("expected case-insensitive match, got %v", err)
+	}
+	err := checkDomain(kindShortURL, "s.ez")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "s.ee"?`) {
+		t.Errorf("expected suggestion, got %v", err)
+	}
+	// A stale list is still used when the API cannot be reached.
+	prev := settings["cache_ttl"]
+	settings["cache_ttl"] = settingValue{Value: "1ns"}
+	defer func() { settings["cache_ttl"] = prev }()
+	err = checkDomain(kindShortURL, "s.ez")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "s.ee"?`) {
+		t.Errorf("expected the stale list to be used, got %v", err)
+	}
+	if err := checkDomain(kindShortURL, "go.example.com"); err != nil {
+		t.Errorf("unexpected error with the stale list: %v", err)
+	}
+
+	// Without cached text domains and without an API, the server decides.
+	if err := checkDomain(kindText, "anything.example"); err != nil {
+		t.Errorf("expected check to be skipped, got %v", err)
+	}
+}
+
+func TestCompleteDomains(t *testing.T) {
+	withTestDomains(t, kindText, "t.example", "s.ee")
+
+	got, _ := completeDomains(kindText)(&cobra.Command{}, nil, "")
+	if want := []string{"t.example", "s.ee"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
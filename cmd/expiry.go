@@ -0,0 +1,193 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: expiry.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:46:43
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:46:43
+//
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// expiryOpts holds the --expire-at, --expire-in and --tz flags of a command.
+type expiryOpts struct {
+	at string
+	in string
+	tz string
+}
+
+// addExpiryFlags registers the expiry flags on cmd.
+func addExpiryFlags(cmd *cobra.Command, e *expiryOpts) {
+	cmd.Flags().StringVar(&e.at, "expire-at", "", "Expire at a date: RFC3339, YYYY-MM-DD [HH:MM], today/tomorrow [HH:MM] or unix seconds")
+	cmd.Flags().StringVar(&e.in, "expire-in", "", "Expire after a duration, e.g. 36h, 7d, 2w or 1d12h")
+	cmd.Flags().StringVar(&e.tz, "tz", "", "Time zone of --expire-at dates, e.g. Europe/Berlin (default: local time)")
+	cmd.MarkFlagsMutuallyExclusive("expire-at", "expire-in")
+}
+
+// expireAt returns the unix expiry selected by the flags, or 0 when none was
+// given. The expiry must lie in the future.
+func (e *expiryOpts) expireAt(now time.Time) (int64, error) {
+	var (
+		t   time.Time
+		err error
+	)
+	switch {
+	case e.in != "":
+		d, err := parseRelDuration(e.in)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --expire-in: %w", err)
+		}
+		t = now.Add(d)
+	case e.at != "":
+		loc := time.Local
+		if e.tz != "" {
+			if loc, err = time.LoadLocation(e.tz); err != nil {
+				return 0, fmt.Errorf("invalid --tz %q: %w", e.tz, err)
+			}
+		}
+		if t, err = parseExpireTime(e.at, now, loc); err != nil {
+			return 0, fmt.Errorf("invalid --expire-at: %w", err)
+		}
+	default:
+		return 0, nil
+	}
+	if !t.After(now) {
+		return 0, fmt.Errorf("expiry %s is in the past", t.Format(time.RFC3339))
+	}
+	return t.Unix(), nil
+}
+
+// parseExpireTime parses an absolute point in time. Accepted forms are unix
+// seconds, RFC3339, "YYYY-MM-DD", "YYYY-MM-DD HH:MM[:SS]" and "today" or
+// "tomorrow" optionally followed by "HH:MM". Forms without a zone are read
+// in loc.
+func parseExpireTime(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, ok := parseFixedTime(s, loc); ok {
+		return t, nil
+	}
+
+	day, clock, _ := strings.Cut(strings.ToLower(s), " ")
+	var offset int
+	switch day {
+	case "today":
+	case "tomorrow":
+		offset = 1
+	default:
+		return time.Time{}, fmt.Errorf("unrecognized date %q: use RFC3339, YYYY-MM-DD [HH:MM], today/tomorrow [HH:MM] or unix seconds", s)
+	}
+	var hour, minute int
+	if clock = strings.TrimSpace(clock); clock != "" {
+		c, err := time.Parse("15:04", clock)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time of day %q: use HH:MM", clock)
+		}
+		hour, minute = c.Hour(), c.Minute()
+	}
+	n := now.In(loc)
+	return time.Date(n.Year(), n.Month(), n.Day()+offset, hour, minute, 0, 0, loc), nil
+}
+
+// parseFixedTime parses the forms of parseExpireTime that name the same
+// point in time whenever they are read, i.e. all but "today" and "tomorrow".
+func parseFixedTime(s string, loc *time.Location) (time.Time, bool) {
+	if ts, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(ts, 0), true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseRelDuration parses a positive duration. On top of the units of
+// time.ParseDuration it accepts d for days and w for weeks, e.g. "2w",
+// "1d12h" or "90m".
+func parseRelDuration(s string) (time.Duration, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	var total time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+			i++
+		}
+		j := i
+		for j < len(s) && (s[j] < '0' || s[j] > '9') && s[j] != '.' {
+			j++
+		}
+		if i == 0 || j == i {
+			return 0, fmt.Errorf("invalid duration %q: use e.g. 36h, 7d or 2w", orig)
+		}
+		n, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+		var unit time.Duration
+		switch s[i:j] {
+		case "w":
+			unit = 7 * 24 * time.Hour
+		case "d":
+			unit = 24 * time.Hour
+		default:
+			d, err := time.ParseDuration(s[:j])
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: use e.g. 36h, 7d or 2w", orig)
+			}
+			total += d
+			s = s[j:]
+			continue
+		}
+		total += time.Duration(n * float64(unit))
+		s = s[j:]
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", orig)
+	}
+	return total, nil
+}
+
+// relDurationValue is a pflag.Value for durations that accept days and weeks.
+type relDurationValue time.Duration
+
+func (d *relDurationValue) String() string {
+	if *d == 0 {
+		return "0"
+	}
+	return time.Duration(*d).String()
+}
+
+func (d *relDurationValue) Set(s string) error {
+	v, err := parseRelDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = relDurationValue(v)
+	return nil
+}
+
+func (d *relDurationValue) Type() string {
+	return "duration"
+}
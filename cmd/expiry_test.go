@@ -0,0 +1,111 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: expiry_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:46:43
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:46:43
+//
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"36h", 36 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1d12h", 36 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"90m", 90 * time.Minute},
+		{"1w2d3h30m", 9*24*time.Hour + 3*time.Hour + 30*time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := parseRelDuration(tt.in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.in, tt.want, got)
+		}
+	}
+
+	for _, in := range []string{"", "7", "d", "7y", "0d", "-1d", "1 d"} {
+		if _, err := parseRelDuration(in); err == nil {
+			t.Errorf("%q: expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseExpireTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	now := time.Date(2026, 3, 10, 22, 30, 0, 0, time.UTC) // 23:30 in Berlin
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"1767225600", time.Unix(1767225600, 0)},
+		{"2026-12-31T23:59:00Z", time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC)},
+		{"2026-12-31", time.Date(2026, 12, 31, 0, 0, 0, 0, berlin)},
+		{"2026-12-31 18:00", time.Date(2026, 12, 31, 18, 0, 0, 0, berlin)},
+		{"today 23:45", time.Date(2026, 3, 10, 23, 45, 0, 0, berlin)},
+		{"tomorrow", time.Date(2026, 3, 11, 0, 0, 0, 0, berlin)},
+		{"Tomorrow 18:00", time.Date(2026, 3, 11, 18, 0, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		got, err := parseExpireTime(tt.in, now, berlin)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.in, tt.want, got)
+		}
+	}
+
+	for _, in := range []string{"next week", "2026-13-01", "tomorrow 25:00", "31/12/2026"} {
+		if _, err := parseExpireTime(in, now, berlin); err == nil {
+			t.Errorf("%q: expected error, got nil", in)
+		}
+	}
+}
+
+func TestExpiryOpts(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	got, err := (&expiryOpts{in: "7d"}).expireAt(now)
+	if err != nil || got != now.Add(7*24*time.Hour).Unix() {
+		t.Errorf("expected now+7d, got %d (%v)", got, err)
+	}
+	got, err = (&expiryOpts{at: "2026-03-10 18:00", tz: "Asia/Tokyo"}).expireAt(now)
+	if err == nil {
+		t.Errorf("expected error for past expiry in Tokyo, got %d", got)
+	}
+	got, err = (&expiryOpts{at: "2026-03-10 18:00", tz: "America/New_York"}).expireAt(now)
+	if err != nil || got != time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC).Unix() {
+		t.Errorf("expected 18:00 in New York, got %d (%v)", got, err)
+	}
+	if _, err := (&expiryOpts{at: "tomorrow", tz: "Mars/Olympus"}).expireAt(now); err == nil {
+		t.Error("expected error for unknown time zone")
+	}
+	if got, err := (&expiryOpts{}).expireAt(now); err != nil || got != 0 {
+		t.Errorf("expected no expiry, got %d (%v)", got, err)
+	}
+}
@@ -0,0 +1,123 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: fetch.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:13:50
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:21:10
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// publicTransport returns a transport for shared content, which needs no API
+// key, with the same retries as API calls and timeout applied to each
+// attempt.
+func publicTransport(timeout time.Duration) *retryTransport {
+	transport := newRetryTransport(retryPolicy{
+		retries: rootOpts.retries,
+		maxWait: rootOpts.retryWait,
+	}, timeout)
+	if rootOpts.quiet {
+		transport.log = io.Discard
+	}
+	return transport
+}
+
+// publicClient fetches shared content with the request timeout.
+var publicClient = func() *http.Client {
+	return &http.Client{Transport: publicTransport(rootOpts.timeout)}
+}
+
+// downloadClient fetches files, which can take longer than the request
+// timeout allows; the timeout only bounds the wait for the response headers.
+var downloadClient = func() *http.Client {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.ResponseHeaderTimeout = rootOpts.timeout
+	transport := publicTransport(0)
+	transport.base = base
+	return &http.Client{Transport: transport}
+}
+
+// contentURL returns the public URL of slug on domain. A slug that is a
+// URL already is returned as is.
+func contentURL(domain, slug string) string {
+	if strings.HasPrefix(slug, "https://") || strings.HasPrefix(slug, "http://") {
+		return slug
+	}
+	return "https://" + domain + "/" + strings.TrimPrefix(slug, "/")
+}
+
+// fetchContent returns the body and content type of a GET of rawURL. Plain
+// text is preferred over a page.
+func fetchContent(rawURL string) ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	req.Header.Set("User-Agent", "see-cli/"+BuildVersion)
+	req.Header.Set("Accept", "text/plain, */*;q=0.5")
+	resp, err := publicClient().Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to fetch %s: %s", rawURL, resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	return b, resp.Header.Get("Content-Type"), nil
+}
+
+// resolveRedirect requests rawURL without following a redirect. It returns
+// the status and, for a redirect, its absolute target.
+func resolveRedirect(rawURL string) (int, string, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	req.Header.Set("User-Agent", "see-cli/"+BuildVersion)
+	client := publicClient()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		if loc, err := resp.Location(); err == nil {
+			return resp.StatusCode, loc.String(), nil
+		}
+	}
+	return resp.StatusCode, "", nil
+}
+
+// pasteContent returns the raw content of a fetched paste. The API has no
+// endpoint that reads a paste, so only a body served as plain text is taken;
+// for a web page ok is false, as its markup is not part of the API and
+// cannot be relied on.
+func pasteContent(body []byte, contentType string) ([]byte, bool) {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "text/html" {
+		return nil, false
+	}
+	return body, true
+}
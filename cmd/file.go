@@ -9,13 +9,17 @@
 // File Created: 2026-01-19 18:36:26
 //
 // Modified By: S.EE Development Team <dev@s.ee>
-// Last Modified: 2026-01-19 19:25:41
+// Last Modified: 2026-10-18 09:19:14
 //
 
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
@@ -26,9 +30,22 @@ import (
 
 var (
 	fileUploadOpts struct {
-		file      string
-		name      string
-		isPrivate int
+		file        string
+		name        string
+		isPrivate   int
+		batch       batchOpts
+		failFast    bool
+		recursive   bool
+		walk        walkOpts
+		archive     string
+		archiveName string
+		encrypt     bool
+		// key encrypts the uploads when --encrypt is set
+		key []byte
+	}
+
+	fileDeleteOpts struct {
+		batch batchOpts
 	}
 
 	fileHistoryOpts struct {
@@ -44,7 +61,35 @@ var fileCmd = &cobra.Command{
 var fileUploadCmd = &cobra.Command{
 	Use:   "upload [file...]",
 	Short: "Upload one or more files",
+	Long: `Upload one or more files, or stdin with --name.
+
+Several files are uploaded in parallel (see --concurrency and --rate). A
+failed upload does not stop the others unless --fail-fast is set; a summary
+of every file is printed at the end, and the command fails if any upload did.
+
+With -r, directories are walked. Hidden files and symlinks are skipped unless
+--hidden or --follow-symlinks is set, --include and --exclude filter by glob,
+and .seeignore files exclude paths with the syntax of .gitignore. The
+summary keeps each file's path below the directory.
+
+With --archive zip or tar.gz, the files and directories are packed into a
+single archive that is streamed into the upload as it is written, without a
+temporary file. Directories are always walked, with the same filters as -r,
+and the list of archived files is kept in the local ledger.
+
+With --encrypt, files are encrypted with AES-256-GCM in 64 KiB chunks as they
+are uploaded, under a random key printed once for all of them, and get an
+.enc suffix. Restore them with 'see file decrypt'.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		fileUploadOpts.key = nil
+		if fileUploadOpts.encrypt {
+			key, err := newKey()
+			if err != nil {
+				return err
+			}
+			fileUploadOpts.key = key
+		}
+
 		// Collect all files to upload
 		var filesToUpload []string
 		filesToUpload = append(filesToUpload, args...)
@@ -54,6 +99,9 @@ var fileUploadCmd = &cobra.Command{
 
 		// Case 1: Stdin
 		if len(filesToUpload) == 0 || (len(filesToUpload) == 1 && filesToUpload[0] == "-") {
+			if fileUploadOpts.archive != "" {
+				return fmt.Errorf("--archive needs files or directories to pack, not stdin")
+			}
 			if fileUploadOpts.name == "" {
 				return fmt.Errorf("filename must be provided via --name when reading from stdin")
 			}
@@ -65,55 +113,202 @@ var fileUploadCmd = &cobra.Command{
 			return fmt.Errorf("cannot use --name with multiple files")
 		}
 
+		var paths []string
 		for _, filePath := range filesToUpload {
-			if filePath == "-" {
-				continue // Skip explicit stdin marker in multi-file mode or handle it? simplified to skip/error
+			if filePath != "-" { // stdin cannot be mixed with files
+				paths = append(paths, filePath)
 			}
-
-			f, err := os.Open(filePath)
-			if err != nil {
-				return fmt.Errorf("failed to open file %q: %w", filePath, err)
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("stdin cannot be uploaded together with files")
+		}
+		if fileUploadOpts.archive != "" {
+			if fileUploadOpts.name != "" {
+				return fmt.Errorf("cannot use --name with --archive: use --archive-name")
 			}
-			// We defer close inside a loop, which is not ideal for many files, but fine for CLI typical usage.
-			// Better to wrap in func.
-			err = func() error {
-				defer f.Close()
-				filename := filepath.Base(filePath)
-				// If strictly single file and name is provided (already checked above for >1), use it.
-				// But we checked >1. If len==1, we can use name.
-				if len(filesToUpload) == 1 && fileUploadOpts.name != "" {
-					filename = fileUploadOpts.name
-				}
-				return uploadReader(cmd, filename, f)
-			}()
-			if err != nil {
+			return uploadArchive(cmd, paths)
+		}
+		if fileUploadOpts.archiveName != "" {
+			return fmt.Errorf("--archive-name needs --archive")
+		}
+		inputs, err := expandUploadPaths(paths, fileUploadOpts.recursive, fileUploadOpts.walk)
+		if err != nil {
+			return err
+		}
+		if len(inputs) == 0 {
+			return fmt.Errorf("no files to upload: all were skipped or ignored")
+		}
+		if len(inputs) > 1 || inputs[0].rel != "" {
+			if fileUploadOpts.name != "" {
+				return fmt.Errorf("cannot use --name with multiple files")
+			}
+			if err := fileUploadOpts.batch.check(); err != nil {
 				return err
 			}
+			return uploadFiles(cmd, inputs)
+		}
+
+		// Case 3: Single file, printed as it is uploaded
+		f, err := os.Open(paths[0])
+		if err != nil {
+			return fmt.Errorf("failed to open file %q: %w", paths[0], err)
 		}
-		return nil
+		defer f.Close()
+		filename := filepath.Base(paths[0])
+		if fileUploadOpts.name != "" {
+			filename = fileUploadOpts.name
+		}
+		return uploadReader(cmd, filename, f)
 	},
 }
 
 func uploadReader(cmd *cobra.Command, filename string, reader io.Reader) error {
-	resp, err := apiClient.UploadFile(seesdk.UploadFileRequest{
-		Filename:  filename,
-		File:      reader,
-		IsPrivate: fileUploadOpts.isPrivate != 0,
-	})
+	p := newProgress(cmd, readerSize(reader))
+	resp, err := uploadFile(cmd, filename, reader, p)
+	p.close()
 	if err != nil {
 		return err
 	}
+	return renderUpload(cmd, filename, resp)
+}
 
-	if rootOpts.jsonOutput {
-		return printJSON(cmd.OutOrStdout(), resp.Data)
+// uploadFile uploads the content of reader, reporting to p, and records it
+// in the ledger.
+func uploadFile(cmd *cobra.Command, filename string, reader io.Reader, p *progress) (*seesdk.UploadFileResponse, error) {
+	size := readerSize(reader)
+	if fileUploadOpts.key != nil && size >= 0 {
+		size = encryptedSize(size)
+	}
+	// The wrappers below hide the file from the SDK's own size check, so an
+	// oversized file would be sent in full before the server rejects it.
+	if size > maxUploadSize {
+		return nil, fmt.Errorf("%s is %s, over the %s upload limit", filename, formatSize(size), formatSize(maxUploadSize))
 	}
+	if fileUploadOpts.key != nil {
+		enc, err := newEncryptReader(fileUploadOpts.key, reader)
+		if err != nil {
+			return nil, err
+		}
+		reader = enc
+		filename += fileEncExt
+	}
+	sum := newChecksumReader(reader)
+	tracked := p.track(filename, size, sum)
+	defer p.finish(tracked)
+	var resp *seesdk.UploadFileResponse
+	err := retryStream(tracked, func(body io.Reader) (err error) {
+		resp, err = apiClient.UploadFile(seesdk.UploadFileRequest{
+			Filename:  filename,
+			File:      body,
+			IsPrivate: fileUploadOpts.isPrivate != 0,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ledgerCreated(cmd, ledgerRecord{
+		Kind:      kindFile,
+		URL:       resp.Data.URL,
+		Filename:  filename,
+		Size:      int64(resp.Data.Size),
+		DeleteKey: resp.Data.Delete,
+		Page:      resp.Data.Page,
+		Encrypted: fileUploadOpts.key != nil,
+		SHA256:    sum.sum(),
+	})
+	return resp, nil
+}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "File uploaded successfully: %s\n", filename)
-	fmt.Fprintf(cmd.OutOrStdout(), "URL: %s\n", resp.Data.URL)
-	fmt.Fprintf(cmd.OutOrStdout(), "Delete Key: %s\n", resp.Data.Delete)
-	fmt.Fprintf(cmd.OutOrStdout(), "Page: %s\n", resp.Data.Page)
-	fmt.Fprintln(cmd.OutOrStdout(), "---")
-	return nil
+// uploadKey returns the key of encrypted uploads as printed, or "".
+func uploadKey() string {
+	if fileUploadOpts.key == nil {
+		return ""
+	}
+	return encodeKey(fileUploadOpts.key)
+}
+
+// encryptedUpload is what an upload renders when it was encrypted.
+type encryptedUpload struct {
+	seesdk.UploadFileData
+	Key string `json:"key"`
+}
+
+// maxUploadSize is the largest file the API accepts.
+const maxUploadSize = 100 << 20
+
+// readerSize returns the size of a regular file, or -1 for other readers.
+func readerSize(r io.Reader) int64 {
+	if f, ok := r.(*os.File); ok {
+		if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() {
+			return fi.Size()
+		}
+	}
+	return -1
+}
+
+// checksumReader computes the SHA-256 of the bytes read through it. A
+// rewind to the start, as retryStream does, starts the checksum over.
+type checksumReader struct {
+	r io.Reader
+	h hash.Hash
+	// valid is false after a seek elsewhere than the start
+	valid bool
+}
+
+func newChecksumReader(r io.Reader) *checksumReader {
+	return &checksumReader{r: r, h: sha256.New(), valid: true}
+}
+
+func (c *checksumReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.h.Write(b[:n])
+	return n, err
+}
+
+func (c *checksumReader) Seek(offset int64, whence int) (int64, error) {
+	s, ok := c.r.(io.Seeker)
+	if !ok {
+		return 0, errors.New("seek not supported")
+	}
+	pos, err := s.Seek(offset, whence)
+	if err == nil {
+		c.h.Reset()
+		c.valid = pos == 0
+	}
+	return pos, err
+}
+
+// sum returns the hex checksum of the bytes read, or "" if it is unknown.
+func (c *checksumReader) sum() string {
+	if !c.valid {
+		return ""
+	}
+	return hex.EncodeToString(c.h.Sum(nil))
+}
+
+// renderUpload prints the result of an upload.
+func renderUpload(cmd *cobra.Command, filename string, resp *seesdk.UploadFileResponse) error {
+	var data any = resp.Data
+	key := uploadKey()
+	if key != "" {
+		data = encryptedUpload{UploadFileData: resp.Data, Key: key}
+	}
+	return render(cmd, result{
+		data:    data,
+		columns: []string{"filename", "url", "delete", "page"},
+		text: func(w io.Writer) error {
+			fmt.Fprintf(w, "File uploaded successfully: %s\n", filename)
+			fmt.Fprintf(w, "URL: %s\n", resp.Data.URL)
+			fmt.Fprintf(w, "Delete Key: %s\n", resp.Data.Delete)
+			fmt.Fprintf(w, "Page: %s\n", resp.Data.Page)
+			if key != "" {
+				fmt.Fprintf(w, "Key: %s\n", key)
+			}
+			fmt.Fprintln(w, "---")
+			return nil
+		},
+	})
 }
 
 var fileDeleteCmd = &cobra.Command{
@@ -121,19 +316,31 @@ var fileDeleteCmd = &cobra.Command{
 	Short: "Delete one or more files",
 	Args:  cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		for _, deleteKey := range args {
-			resp, err := apiClient.DeleteFile(deleteKey)
+		if err := fileDeleteOpts.batch.check(); err != nil {
+			return err
+		}
+		type deleteResult struct {
+			resp *seesdk.DeleteFileResponse
+			err  error
+		}
+		var firstErr error
+		runBatch(fileDeleteOpts.batch, len(args), func(i int) deleteResult {
+			resp, err := apiClient.DeleteFile(args[i])
 			if err != nil {
-				return fmt.Errorf("failed to delete file with key %q: %w", deleteKey, err)
+				return deleteResult{err: fmt.Errorf("failed to delete file with key %q: %w", args[i], err)}
 			}
-
-			if rootOpts.jsonOutput {
-				printJSON(cmd.OutOrStdout(), resp)
-			} else {
-				fmt.Fprintf(cmd.OutOrStdout(), "File with key arg %q deleted successfully\n", deleteKey)
+			ledgerFileDeleted(cmd, args[i])
+			return deleteResult{resp: resp}
+		}, func(i int, r deleteResult) bool {
+			if r.err == nil {
+				r.err = render(cmd, messageResult(r.resp, fmt.Sprintf("File with key arg %q deleted successfully", args[i])))
 			}
-		}
-		return nil
+			if r.err != nil && firstErr == nil {
+				firstErr = r.err
+			}
+			return r.err == nil
+		})
+		return firstErr
 	},
 }
 
@@ -141,17 +348,7 @@ var fileDomainsCmd = &cobra.Command{
 	Use:   "domains",
 	Short: "List available file domains",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		resp, err := apiClient.GetFileDomains()
-		if err != nil {
-			return err
-		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp.Data.Domains)
-		}
-		for _, d := range resp.Data.Domains {
-			fmt.Fprintln(cmd.OutOrStdout(), d)
-		}
-		return nil
+		return listDomains(cmd, kindFile)
 	},
 }
 
@@ -164,18 +361,21 @@ var fileHistoryCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp.Data)
-		}
-		for _, f := range resp.Data {
-			fmt.Fprintf(cmd.OutOrStdout(), "File: %s\n", f.Filename)
-			fmt.Fprintf(cmd.OutOrStdout(), "URL: %s\n", f.URL)
-			fmt.Fprintf(cmd.OutOrStdout(), "Delete Key: %s\n", f.Delete)
-			fmt.Fprintf(cmd.OutOrStdout(), "Size: %d\n", f.Size)
-			fmt.Fprintf(cmd.OutOrStdout(), "Page: %s\n", f.Page)
-			fmt.Fprintln(cmd.OutOrStdout(), "---")
-		}
-		return nil
+		return render(cmd, result{
+			data:    resp.Data,
+			columns: []string{"filename", "url", "delete", "size", "page"},
+			text: func(w io.Writer) error {
+				for _, f := range resp.Data {
+					fmt.Fprintf(w, "File: %s\n", f.Filename)
+					fmt.Fprintf(w, "URL: %s\n", f.URL)
+					fmt.Fprintf(w, "Delete Key: %s\n", f.Delete)
+					fmt.Fprintf(w, "Size: %d\n", f.Size)
+					fmt.Fprintf(w, "Page: %s\n", f.Page)
+					fmt.Fprintln(w, "---")
+				}
+				return nil
+			},
+		})
 	},
 }
 
@@ -185,11 +385,28 @@ func init() {
 	fileCmd.AddCommand(fileDomainsCmd)
 	fileCmd.AddCommand(fileHistoryCmd)
 
+	fileDeleteCmd.ValidArgsFunction = completeDeleteKeys
+
 	fileUploadCmd.Flags().StringVarP(&fileUploadOpts.file, "file", "f", "", "Path to file to upload (default stdin if not provided or -)")
 	fileUploadCmd.Flags().StringVarP(&fileUploadOpts.name, "name", "n", "", "Filename to use (required for stdin, optional override for file)")
 	fileUploadCmd.Flags().IntVar(&fileUploadOpts.isPrivate, "is-private", 0, "Whether this file should be private (0 = public, 1 = private)")
 	fileUploadCmd.Flags().IntVar(&fileUploadOpts.isPrivate, "private", 0, "Alias for --is-private")
 	fileUploadCmd.Flags().MarkHidden("private")
+	bindSetting(fileUploadCmd.Flags(), "is-private", "file_private")
+	bindSetting(fileUploadCmd.Flags(), "private", "file_private")
+	addBatchFlags(fileUploadCmd, &fileUploadOpts.batch)
+	fileUploadCmd.Flags().BoolVar(&fileUploadOpts.failFast, "fail-fast", false, "Stop starting uploads after the first failure")
+	fileUploadCmd.Flags().BoolVarP(&fileUploadOpts.recursive, "recursive", "r", false, "Upload the files in directories and their subdirectories")
+	fileUploadCmd.Flags().StringSliceVar(&fileUploadOpts.walk.include, "include", nil, "With -r, only upload files matching these globs, e.g. '*.png' or 'shots/**/*.png'")
+	fileUploadCmd.Flags().StringSliceVar(&fileUploadOpts.walk.exclude, "exclude", nil, "With -r, skip files and directories matching these globs")
+	fileUploadCmd.Flags().BoolVar(&fileUploadOpts.walk.hidden, "hidden", false, "With -r, include hidden files and directories")
+	fileUploadCmd.Flags().BoolVar(&fileUploadOpts.walk.followSymlinks, "follow-symlinks", false, "With -r, upload the files that symlinks point to")
+	fileUploadCmd.Flags().StringVar(&fileUploadOpts.archive, "archive", "", "Upload the files as one archive streamed as it is packed (zip or tar.gz)")
+	fileUploadCmd.Flags().StringVar(&fileUploadOpts.archiveName, "archive-name", "", "Filename of the archive (default the directory name, or files-<time>)")
+	fileUploadCmd.RegisterFlagCompletionFunc("archive", completeArchiveFormats)
+	fileUploadCmd.Flags().BoolVar(&fileUploadOpts.encrypt, "encrypt", false, "Encrypt the files locally as they are uploaded; the key is printed once")
+
+	addBatchFlags(fileDeleteCmd, &fileDeleteOpts.batch)
 
 	fileHistoryCmd.Flags().IntVarP(&fileHistoryOpts.page, "page", "p", 1, "Page number (default 1, 30 files per page)")
 }
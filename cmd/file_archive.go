@@ -0,0 +1,239 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_archive.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:11:55
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:16:07
+//
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+// Archive formats of file upload --archive.
+const (
+	archiveZip   = "zip"
+	archiveTarGz = "tar.gz"
+)
+
+// archiveEntry is a file to add to an archive under name.
+type archiveEntry struct {
+	path string
+	name string
+}
+
+// archiveUpload is what file upload --archive renders.
+type archiveUpload struct {
+	seesdk.UploadFileData
+	Filename string   `json:"filename"`
+	Files    []string `json:"files"`
+	Key      string   `json:"key,omitempty"`
+}
+
+// uploadArchive streams an archive of paths into an upload, without a
+// temporary file, and records the archived files in the ledger.
+func uploadArchive(cmd *cobra.Command, paths []string) error {
+	format := fileUploadOpts.archive
+	if format != archiveZip && format != archiveTarGz {
+		return fmt.Errorf("invalid --archive %q: use zip or tar.gz", format)
+	}
+	entries, err := archiveEntries(paths, fileUploadOpts.walk)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no files to archive: all were skipped or ignored")
+	}
+	name := archiveName(fileUploadOpts.archiveName, format, paths, time.Now())
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeArchive(pw, format, entries))
+	}()
+	p := newProgress(cmd, -1)
+	resp, err := uploadFile(cmd, name, pr, p)
+	p.close()
+	// Stops the archive writer if the upload ended early.
+	pr.Close()
+	if err != nil {
+		return err
+	}
+
+	files := make([]string, len(entries))
+	for i, e := range entries {
+		files[i] = e.name
+	}
+	ledgerFileUpdated(cmd, resp.Data.Delete, func(r *ledgerRecord) {
+		r.Files = files
+	})
+	return render(cmd, result{
+		data:    archiveUpload{UploadFileData: resp.Data, Filename: name, Files: files, Key: uploadKey()},
+		columns: []string{"filename", "url", "delete", "page"},
+		text: func(w io.Writer) error {
+			fmt.Fprintf(w, "Archive uploaded successfully: %s (%d files)\n", name, len(files))
+			fmt.Fprintf(w, "URL: %s\n", resp.Data.URL)
+			fmt.Fprintf(w, "Delete Key: %s\n", resp.Data.Delete)
+			fmt.Fprintf(w, "Page: %s\n", resp.Data.Page)
+			if key := uploadKey(); key != "" {
+				fmt.Fprintf(w, "Key: %s\n", key)
+			}
+			for _, f := range files {
+				fmt.Fprintf(w, "  %s\n", f)
+			}
+			return nil
+		},
+	})
+}
+
+// archiveEntries lists the files of paths. Files are stored under their base
+// name and directories, which are always walked, under their own name, so
+// that logs/app.log stays logs/app.log in the archive.
+func archiveEntries(paths []string, opts walkOpts) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	seen := map[string]string{}
+	add := func(p, name string) error {
+		if prev, ok := seen[name]; ok {
+			return fmt.Errorf("%s and %s would both be stored as %s in the archive", prev, p, name)
+		}
+		seen[name] = p
+		entries = append(entries, archiveEntry{path: p, name: name})
+		return nil
+	}
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			if err := add(p, filepath.Base(p)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		inputs, err := walkUploadDir(p, opts)
+		if err != nil {
+			return nil, err
+		}
+		base := filepath.Base(filepath.Clean(p))
+		if base == "." || base == string(filepath.Separator) {
+			base = ""
+		}
+		for _, in := range inputs {
+			if err := add(in.path, path.Join(base, in.rel)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return entries, nil
+}
+
+// archiveName returns the file name of the archive: the given name with the
+// format's extension, the name of a single directory, or a dated default.
+func archiveName(name, format string, paths []string, now time.Time) string {
+	ext := "." + format
+	if name == "" {
+		name = "files-" + now.Format("20060102-150405")
+		if len(paths) == 1 {
+			if base := filepath.Base(filepath.Clean(paths[0])); base != "." && base != string(filepath.Separator) {
+				name = strings.TrimSuffix(base, filepath.Ext(base))
+			}
+		}
+	}
+	if !strings.HasSuffix(name, ext) {
+		name += ext
+	}
+	return name
+}
+
+// writeArchive writes entries to w as a zip or tar.gz archive.
+func writeArchive(w io.Writer, format string, entries []archiveEntry) error {
+	if format == archiveZip {
+		zw := zip.NewWriter(w)
+		for _, e := range entries {
+			if err := addZipEntry(zw, e); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, e := range entries {
+		if err := addTarEntry(tw, e); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func addZipEntry(zw *zip.Writer, e archiveEntry) error {
+	f, err := os.Open(e.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := zip.FileInfoHeader(fi)
+	if err != nil {
+		return err
+	}
+	hdr.Name = e.name
+	hdr.Method = zip.Deflate
+	dst, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, f)
+	return err
+}
+
+func addTarEntry(tw *tar.Writer, e archiveEntry) error {
+	f, err := os.Open(e.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = e.name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	// A file that grows while it is archived is cut at the size in the
+	// header, which tar requires.
+	_, err = io.CopyN(tw, f, hdr.Size)
+	return err
+}
@@ -0,0 +1,174 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_archive_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:11:55
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:11:55
+//
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestArchiveEntries(t *testing.T) {
+	dir := filepath.Join(writeTestTree(t, map[string]string{
+		"logs/app.log":       "",
+		"logs/old/app.1.log": "",
+		"logs/.hidden":       "",
+	}), "logs")
+	single := writeTestFiles(t, "notes.txt")[0].path
+
+	entries, err := archiveEntries([]string{dir, single}, walkOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.name)
+	}
+	want := []string{"logs/app.log", "logs/old/app.1.log", "notes.txt"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+
+	if _, err := archiveEntries([]string{single, single}, walkOpts{}); err == nil {
+		t.Error("expected error for duplicate names, got nil")
+	}
+	if _, err := archiveEntries([]string{filepath.Join(dir, "missing")}, walkOpts{}); err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestArchiveName(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name, format string
+		paths        []string
+		want         string
+	}{
+		{"", archiveTarGz, []string{"site/"}, "site.tar.gz"},
+		{"", archiveZip, []string{"report.pdf"}, "report.zip"},
+		{"", archiveZip, []string{"a", "b"}, "files-20261018-093000.zip"},
+		{"backup", archiveTarGz, []string{"a"}, "backup.tar.gz"},
+		{"backup.zip", archiveZip, []string{"a"}, "backup.zip"},
+	}
+	for _, tt := range tests {
+		if got := archiveName(tt.name, tt.format, tt.paths, now); got != tt.want {
+			t.Errorf("archiveName(%q, %q, %v) = %q, want %q", tt.name, tt.format, tt.paths, got, tt.want)
+		}
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	dir := filepath.Join(writeTestTree(t, map[string]string{
+		"docs/a.txt":     "content of a.txt",
+		"docs/sub/b.txt": "content of sub/b.txt",
+	}), "docs")
+	entries, err := archiveEntries([]string{dir}, walkOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"docs/a.txt":     "content of a.txt",
+		"docs/sub/b.txt": "content of sub/b.txt",
+	}
+
+	var zbuf bytes.Buffer
+	if err := writeArchive(&zbuf, archiveZip, entries); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(zbuf.Bytes()), int64(zbuf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		got[f.Name] = string(b)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("zip: expected %v, got %v", want, got)
+	}
+
+	var tbuf bytes.Buffer
+	if err := writeArchive(&tbuf, archiveTarGz, entries); err != nil {
+		t.Fatal(err)
+	}
+	gr, err := gzip.NewReader(&tbuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	got = map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(tr)
+		got[hdr.Name] = string(b)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tar.gz: expected %v, got %v", want, got)
+	}
+}
+
+func TestUploadArchive(t *testing.T) {
+	withTestUploadServer(t)
+	dir := filepath.Join(writeTestTree(t, map[string]string{
+		"site/index.html":   "<h1>hi</h1>",
+		"site/css/main.css": "h1 {}",
+	}), "site")
+	fileUploadOpts.archive = archiveZip
+	fileUploadOpts.archiveName = ""
+	fileUploadOpts.walk = walkOpts{}
+	defer func() { fileUploadOpts.archive = "" }()
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	if err := uploadArchive(cmd, []string{dir}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("https://f.example/site.zip")) {
+		t.Errorf("expected the archive URL:\n%s", out.String())
+	}
+
+	l, err := loadLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := l.findByDeleteKey("key-site.zip")
+	if r == nil {
+		t.Fatal("expected the archive in the ledger")
+	}
+	if want := []string{"site/css/main.css", "site/index.html"}; !reflect.DeepEqual(r.Files, want) {
+		t.Errorf("expected files %v, got %v", want, r.Files)
+	}
+}
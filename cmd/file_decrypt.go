@@ -0,0 +1,132 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_decrypt.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:16:07
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:19:14
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var fileDecryptOpts struct {
+	key   string
+	out   string
+	force bool
+}
+
+var fileDecryptCmd = &cobra.Command{
+	Use:   "decrypt <file|->",
+	Short: "Decrypt a file uploaded with --encrypt",
+	Long: `Decrypt a file uploaded with 'see file upload --encrypt'.
+
+The plaintext is written next to the file without its .enc suffix, to --out,
+or to stdout for stdin or --out -. A file is only written once all of it has
+been decrypted and authenticated, so a wrong key or a modified file leaves
+nothing behind.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := parseKey(fileDecryptOpts.key)
+		if err != nil {
+			return err
+		}
+		in := cmd.InOrStdin()
+		out := fileDecryptOpts.out
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open file %q: %w", args[0], err)
+			}
+			defer f.Close()
+			in = f
+			if out == "" {
+				if !strings.HasSuffix(args[0], fileEncExt) {
+					return fmt.Errorf("%s has no %s suffix: name the output with --out", args[0], fileEncExt)
+				}
+				out = strings.TrimSuffix(args[0], fileEncExt)
+			}
+		}
+		return decryptTo(cmd, key, in, out, fileDecryptOpts.force)
+	},
+}
+
+// decryptTo decrypts r with key into the file out, or to stdout when out is
+// empty or "-".
+func decryptTo(cmd *cobra.Command, key []byte, r io.Reader, out string, force bool) error {
+	if out == "" || out == "-" {
+		dr, err := newDecryptReader(key, r)
+		if err != nil {
+			return err
+		}
+		// Chunks are authenticated before they are written, but a
+		// truncated stream is only detected at its end.
+		_, err = io.Copy(cmd.OutOrStdout(), dr)
+		return err
+	}
+	if _, err := os.Stat(out); err == nil && !force {
+		return fmt.Errorf("%s exists: use --force to overwrite it", out)
+	}
+	if err := writeDecrypted(key, r, out); err != nil {
+		return err
+	}
+	return render(cmd, result{
+		data:    decryptedFile{File: out},
+		columns: []string{"file"},
+		text: func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "Decrypted to %s\n", out)
+			return err
+		},
+	})
+}
+
+// writeDecrypted decrypts r with key into the file out. The file is written
+// under a temporary name and renamed once the whole content is
+// authenticated; like the temporary file, it is only readable by the user.
+func writeDecrypted(key []byte, r io.Reader, out string) error {
+	dr, err := newDecryptReader(key, r)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(out), "."+filepath.Base(out)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, dr); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), out)
+}
+
+// decryptedFile is what file decrypt renders when it writes a file.
+type decryptedFile struct {
+	File string `json:"file"`
+}
+
+func init() {
+	fileCmd.AddCommand(fileDecryptCmd)
+	fileDecryptCmd.Flags().StringVar(&fileDecryptOpts.key, "key", "", "Key printed by file upload --encrypt")
+	fileDecryptCmd.MarkFlagRequired("key")
+	fileDecryptCmd.Flags().StringVar(&fileDecryptOpts.out, "out", "", "Output file, or - for stdout (default the input without .enc)")
+	fileDecryptCmd.Flags().BoolVar(&fileDecryptOpts.force, "force", false, "Overwrite the output file")
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_decrypt_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:16:07
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:19:14
+//
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+func TestUploadEncryptedAndDecrypt(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	var uploaded []byte
+	var uploadedName string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		uploaded, _ = io.ReadAll(f)
+		uploadedName = header.Filename
+		io.WriteString(w, `{"code":200,"data":{"url":"https://f.example/x","delete":"key-x","page":"p"}}`)
+	}))
+	defer srv.Close()
+	prev := apiClient
+	apiClient = seesdk.NewClient(seesdk.Config{BaseURL: srv.URL, APIKey: "k"})
+	defer func() { apiClient = prev }()
+
+	key, _ := newKey()
+	fileUploadOpts.key = key
+	defer func() { fileUploadOpts.key = nil }()
+	plain := bytes.Repeat([]byte("customer log line\n"), 5000)
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	if err := uploadReader(cmd, "app.log", bytes.NewReader(plain)); err != nil {
+		t.Fatal(err)
+	}
+	if uploadedName != "app.log.enc" {
+		t.Errorf("expected the .enc name, got %q", uploadedName)
+	}
+	if bytes.Contains(uploaded, []byte("customer")) {
+		t.Fatal("expected the upload to be encrypted")
+	}
+	if !bytes.Contains(out.Bytes(), []byte("Key: "+encodeKey(key))) {
+		t.Errorf("expected the key in the output:\n%s", out.String())
+	}
+	l, err := loadLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := l.findByDeleteKey("key-x"); r == nil || !r.Encrypted || r.SHA256 != sha256Hex(uploaded) {
+		t.Errorf("expected an encrypted ledger record with the checksum of the upload, got %+v", r)
+	}
+
+	dir := t.TempDir()
+	encPath := filepath.Join(dir, "app.log.enc")
+	if err := os.WriteFile(encPath, uploaded, 0600); err != nil {
+		t.Fatal(err)
+	}
+	fileDecryptOpts.key = encodeKey(key)
+	fileDecryptOpts.out = ""
+	defer func() { fileDecryptOpts.key = "" }()
+	fileDecryptCmd.SetOut(io.Discard)
+	defer fileDecryptCmd.SetOut(nil)
+	if err := fileDecryptCmd.RunE(fileDecryptCmd, []string{encPath}); err != nil {
+		t.Fatalf("file decrypt failed: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("expected the plaintext back, got error %v", err)
+	}
+
+	// An existing file is kept unless --force is set.
+	if err := fileDecryptCmd.RunE(fileDecryptCmd, []string{encPath}); err == nil {
+		t.Error("expected error for an existing output, got nil")
+	}
+}
+
+func TestDecryptToLeavesNothingOnFailure(t *testing.T) {
+	key, _ := newKey()
+	other, _ := newKey()
+	enc := encryptTestBytes(t, key, []byte("secret"))
+	out := filepath.Join(t.TempDir(), "secret.txt")
+	if err := decryptTo(&cobra.Command{}, other, bytes.NewReader(enc), out, false); err == nil {
+		t.Fatal("expected error for the wrong key, got nil")
+	}
+	entries, _ := os.ReadDir(filepath.Dir(out))
+	if len(entries) != 0 {
+		t.Errorf("expected no files after a failed decrypt, got %d", len(entries))
+	}
+}
@@ -0,0 +1,501 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_download.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:19:14
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:19:14
+//
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// partExt is appended to the name of a download until it is complete.
+const partExt = ".part"
+
+// partMetaExt is appended to the name of a part for the file that records
+// what the part was downloaded from.
+const partMetaExt = ".meta"
+
+// maxHistoryPages bounds how far back the upload history is searched for a
+// delete key.
+const maxHistoryPages = 50
+
+var fileDownloadOpts struct {
+	out   string
+	key   string
+	force bool
+}
+
+var fileDownloadCmd = &cobra.Command{
+	Use:   "download <url|delete-key|ledger-id>",
+	Short: "Download an uploaded file",
+	Long: `Download an uploaded file, given by its URL, its delete key or its ID in
+the local ledger.
+
+The file is saved under the name it was uploaded with when the ledger or the
+upload history knows it, or else the last part of its URL. It is written to
+a .part file first: an interrupted download is resumed with a Range request,
+both within the command and when the command is run again. A part is only
+resumed when the server confirms the file has not changed since; otherwise
+the download starts over.
+
+Files uploaded by this CLI are checked against the SHA-256 recorded at
+upload, and a file that does not match is removed. Files uploaded with
+--encrypt are decrypted with --key or the URL's #key.`,
+	Args:              cobra.ExactArgs(1),
+	Annotations:       map[string]string{skipClientAnnotation: "true"},
+	ValidArgsFunction: completeDeleteKeys,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveSettings(cmd); err != nil {
+			return err
+		}
+		src, err := resolveDownload(args[0])
+		if err != nil {
+			return err
+		}
+		keyText := fileDownloadOpts.key
+		if keyText == "" {
+			keyText = src.fragment
+		}
+		var key []byte
+		if keyText != "" {
+			if key, err = parseKey(keyText); err != nil {
+				return err
+			}
+		}
+
+		out := fileDownloadOpts.out
+		if out == "-" {
+			return streamDownload(cmd, src, key)
+		}
+		if out == "" {
+			out = src.filename
+			if key != nil {
+				out = strings.TrimSuffix(out, fileEncExt)
+			}
+		}
+		if _, err := os.Stat(out); err == nil && !fileDownloadOpts.force {
+			return fmt.Errorf("%s exists: use --force to overwrite it", out)
+		}
+
+		part := out + partExt
+		sum, err := fetchToPart(cmd, src.url, part, filepath.Base(out))
+		if err != nil {
+			return err
+		}
+		if src.sha256 != "" && sum != src.sha256 {
+			os.Remove(part)
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s; the download was removed", src.url, src.sha256, sum)
+		}
+		if key != nil {
+			err = decryptPart(key, part, out)
+		} else {
+			err = os.Rename(part, out)
+		}
+		if err != nil {
+			return err
+		}
+
+		res := downloadedFile{File: out, URL: src.url, SHA256: sum, Verified: src.sha256 != "", Decrypted: key != nil}
+		if fi, err := os.Stat(out); err == nil {
+			res.Size = fi.Size()
+		}
+		return render(cmd, result{
+			data:    res,
+			columns: []string{"file", "size", "sha256", "verified"},
+			text: func(w io.Writer) error {
+				fmt.Fprintf(w, "Downloaded %s to %s (%s)\n", res.URL, res.File, formatSize(res.Size))
+				if res.Verified {
+					fmt.Fprintf(w, "SHA-256: %s (verified)\n", res.SHA256)
+				} else {
+					fmt.Fprintf(w, "SHA-256: %s (not verified: no checksum was recorded at upload)\n", res.SHA256)
+				}
+				return nil
+			},
+		})
+	},
+}
+
+// downloadSource is a file to download.
+type downloadSource struct {
+	url      string
+	filename string
+	// sha256 is the checksum recorded at upload, if any
+	sha256 string
+	// fragment is the #fragment of a URL argument, which holds the key of
+	// an encrypted file
+	fragment string
+}
+
+// downloadedFile is what file download renders.
+type downloadedFile struct {
+	File      string `json:"file"`
+	URL       string `json:"url"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+	Verified  bool   `json:"verified"`
+	Decrypted bool   `json:"decrypted,omitempty"`
+}
+
+// resolveDownload finds the file that arg names: a URL, a ledger ID or a
+// delete key. Delete keys missing from the ledger are looked up in the
+// upload history.
+func resolveDownload(arg string) (downloadSource, error) {
+	l, err := loadLedger()
+	if err != nil {
+		return downloadSource{}, err
+	}
+	if strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "http://") {
+		rawURL, fragment, _ := strings.Cut(arg, "#")
+		src := downloadSource{url: rawURL, fragment: fragment}
+		if u, err := url.Parse(rawURL); err == nil {
+			src.filename = safeFilename(path.Base(u.Path))
+		}
+		if r := l.findFileByURL(rawURL); r != nil {
+			src.filename, src.sha256 = safeFilename(r.Filename), r.SHA256
+		}
+		return src, nil
+	}
+
+	r := l.findByDeleteKey(arg)
+	if id, err := strconv.ParseInt(arg, 10, 64); err == nil && r == nil {
+		// A number that is no file's ledger ID may still be a delete key.
+		if byID := l.findByID(id); byID != nil && byID.Kind == kindFile {
+			if byID.DeletedAt != nil {
+				return downloadSource{}, fmt.Errorf("file %d was deleted", id)
+			}
+			r = byID
+		}
+	}
+	if r != nil {
+		return downloadSource{url: r.URL, filename: safeFilename(r.Filename), sha256: r.SHA256}, nil
+	}
+
+	if err := ensureClient(); err != nil {
+		return downloadSource{}, err
+	}
+	for page := 1; page <= maxHistoryPages; page++ {
+		resp, err := apiClient.GetFileHistory(page)
+		if err != nil {
+			return downloadSource{}, fmt.Errorf("failed to look up delete key %q: %w", arg, err)
+		}
+		if len(resp.Data) == 0 {
+			return downloadSource{}, fmt.Errorf("no file with ledger ID or delete key %q", arg)
+		}
+		for _, f := range resp.Data {
+			if f.Delete == arg {
+				return downloadSource{url: f.URL, filename: safeFilename(f.Filename)}, nil
+			}
+		}
+	}
+	return downloadSource{}, fmt.Errorf("no file with delete key %q in the last %d pages of the upload history: pass its URL instead", arg, maxHistoryPages)
+}
+
+// safeFilename reduces a name from the ledger, the API or a URL to a file
+// name in the current directory.
+func safeFilename(name string) string {
+	name = filepath.Base(filepath.FromSlash(name))
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		return "download"
+	}
+	return name
+}
+
+// interruptedError is a download that failed while the body was read, which
+// can be resumed.
+type interruptedError struct {
+	err error
+}
+
+func (e *interruptedError) Error() string {
+	return "download interrupted: " + e.err.Error()
+}
+
+func (e *interruptedError) Unwrap() error {
+	return e.err
+}
+
+// fetchToPart downloads rawURL into part, resuming from what part holds,
+// and returns the SHA-256 of the whole file. Interruptions are resumed up to
+// --retries times.
+func fetchToPart(cmd *cobra.Command, rawURL, part, name string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		sum, err := fetchPartOnce(cmd, rawURL, part, name)
+		var interrupted *interruptedError
+		if err == nil || !errors.As(err, &interrupted) || attempt >= rootOpts.retries {
+			return sum, err
+		}
+		if !rootOpts.quiet {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%v, resuming (%d/%d)\n", err, attempt+1, rootOpts.retries)
+		}
+	}
+}
+
+// partMeta records what a part file was downloaded from, so that it is only
+// resumed from the same version of the same file.
+type partMeta struct {
+	URL string `json:"url"`
+	// Validator is the strong ETag or the Last-Modified date of the
+	// response, sent as If-Range on resume
+	Validator string `json:"validator,omitempty"`
+	// Size is the size of the whole file, or -1 if unknown
+	Size int64 `json:"size"`
+}
+
+// readPartMeta returns the record of part, or nil if there is none.
+func readPartMeta(part string) *partMeta {
+	b, err := os.ReadFile(part + partMetaExt)
+	if err != nil {
+		return nil
+	}
+	m := &partMeta{}
+	if json.Unmarshal(b, m) != nil {
+		return nil
+	}
+	return m
+}
+
+// writePartMeta records what part is being downloaded from.
+func writePartMeta(part string, m partMeta) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(part+partMetaExt, b, 0644)
+}
+
+// responseValidator returns the value of If-Range that resumes the
+// response's content: its ETag if strong, or else its Last-Modified date.
+func responseValidator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// fetchPartOnce appends the rest of rawURL to part with a single request. A
+// part is only resumed when its record names the same URL and the server
+// confirms with If-Range and Content-Range that the file is unchanged;
+// otherwise it is started over.
+func fetchPartOnce(cmd *cobra.Command, rawURL, part, name string) (string, error) {
+	f, err := os.OpenFile(part, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	// Hashing what is there already leaves the file positioned at its end.
+	offset, err := io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	meta := readPartMeta(part)
+	if offset > 0 && (meta == nil || meta.URL != rawURL) {
+		// Left by another file or an older version of this CLI.
+		if err := restartPart(f, h); err != nil {
+			return "", err
+		}
+		offset = 0
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	req.Header.Set("User-Agent", "see-cli/"+BuildVersion)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if meta.Validator != "" {
+			req.Header.Set("If-Range", meta.Validator)
+		}
+	}
+	resp, err := downloadClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if offset == 0 {
+			return "", fmt.Errorf("failed to download %s: unexpected partial content", rawURL)
+		}
+		if !ok || start != offset || meta.Size >= 0 && total != meta.Size {
+			// Not the rest of the file the part holds.
+			return restartDownload(cmd, rawURL, part, name, f, resp)
+		}
+	case http.StatusOK:
+		// The server ignored the range or the file changed: start over.
+		if offset > 0 {
+			if err := restartPart(f, h); err != nil {
+				return "", err
+			}
+		}
+		if err := writePartMeta(part, partMeta{URL: rawURL, Validator: responseValidator(resp), Size: resp.ContentLength}); err != nil {
+			return "", err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The part might be complete, but might as well hold more than the
+		// file now has: it is never taken as done.
+		if offset > 0 {
+			return restartDownload(cmd, rawURL, part, name, f, resp)
+		}
+		fallthrough
+	default:
+		return "", fmt.Errorf("failed to download %s: %s", rawURL, resp.Status)
+	}
+
+	p := newProgress(cmd, resp.ContentLength)
+	tracked := p.track(name, resp.ContentLength, resp.Body)
+	_, err = io.Copy(io.MultiWriter(f, h), tracked)
+	p.finish(tracked)
+	p.close()
+	if err != nil {
+		return "", &interruptedError{err: err}
+	}
+	os.Remove(part + partMetaExt)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// restartDownload drops the part f, which resp showed not to match rawURL,
+// and downloads the file again from the start.
+func restartDownload(cmd *cobra.Command, rawURL, part, name string, f *os.File, resp *http.Response) (string, error) {
+	resp.Body.Close()
+	if err := restartPart(f, sha256.New()); err != nil {
+		return "", err
+	}
+	f.Close()
+	if !rootOpts.quiet {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s does not continue the partial download, starting over\n", rawURL)
+	}
+	return fetchPartOnce(cmd, rawURL, part, name)
+}
+
+// restartPart empties part, its checksum and its record.
+func restartPart(f *os.File, h hash.Hash) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	h.Reset()
+	if err := os.Remove(f.Name() + partMetaExt); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// parseContentRange returns the first byte and the total size of a
+// Content-Range such as "bytes 100-199/200". The total is -1 when it is
+// given as "*".
+func parseContentRange(v string) (start, total int64, ok bool) {
+	spec, ok := strings.CutPrefix(v, "bytes ")
+	if !ok {
+		return 0, 0, false
+	}
+	first, rest, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	_, size, ok := strings.Cut(rest, "/")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if size == "*" {
+		return start, -1, true
+	}
+	if total, err = strconv.ParseInt(size, 10, 64); err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
+
+// decryptPart decrypts the completed download part into out and removes it.
+func decryptPart(key []byte, part, out string) error {
+	f, err := os.Open(part)
+	if err != nil {
+		return err
+	}
+	err = writeDecrypted(key, f, out)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Remove(part)
+}
+
+// streamDownload writes the file to stdout, decrypting it with key if set.
+// It cannot resume, and a checksum mismatch is only reported once the
+// content has been written.
+func streamDownload(cmd *cobra.Command, src downloadSource, key []byte) error {
+	req, err := http.NewRequest(http.MethodGet, src.url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", src.url, err)
+	}
+	req.Header.Set("User-Agent", "see-cli/"+BuildVersion)
+	resp, err := downloadClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", src.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", src.url, resp.Status)
+	}
+
+	h := sha256.New()
+	var r io.Reader = io.TeeReader(resp.Body, h)
+	if key != nil {
+		dr, err := newDecryptReader(key, r)
+		if err != nil {
+			return err
+		}
+		r = dr
+	}
+	if _, err := io.Copy(cmd.OutOrStdout(), r); err != nil {
+		return err
+	}
+	if _, err := io.Copy(h, resp.Body); err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); src.sha256 != "" && sum != src.sha256 {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", src.url, src.sha256, sum)
+	}
+	return nil
+}
+
+func init() {
+	fileCmd.AddCommand(fileDownloadCmd)
+	fileDownloadCmd.Flags().StringVar(&fileDownloadOpts.out, "out", "", "Output file, or - for stdout (default the uploaded name)")
+	fileDownloadCmd.Flags().StringVar(&fileDownloadOpts.key, "key", "", "Key of a file uploaded with --encrypt")
+	fileDownloadCmd.Flags().BoolVar(&fileDownloadOpts.force, "force", false, "Overwrite the output file")
+}
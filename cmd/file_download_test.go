@@ -0,0 +1,328 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_download_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:19:14
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:19:14
+//
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+// testFileServer serves files with Range support and records the Range
+// header of every request. Files carry etag as their ETag. With cutFirst,
+// the first response stops halfway through the body.
+type testFileServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	ranges   []string
+	cutFirst bool
+	etag     string
+}
+
+func newTestFileServer(t *testing.T, files map[string][]byte) *testFileServer {
+	t.Helper()
+	s := &testFileServer{etag: `"v1"`}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		cut := s.cutFirst && len(s.ranges) == 1
+		s.mu.Unlock()
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if cut {
+			// Declaring the full length and sending half makes the server
+			// drop the connection.
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.Write(content[:len(content)/2])
+			return
+		}
+		s.mu.Lock()
+		w.Header().Set("ETag", s.etag)
+		s.mu.Unlock()
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// runDownload runs file download for arg, writing to out.
+func runDownload(t *testing.T, arg, out string) error {
+	t.Helper()
+	fileDownloadOpts.out = out
+	fileDownloadOpts.key = ""
+	fileDownloadOpts.force = false
+	fileDownloadCmd.SetOut(io.Discard)
+	t.Cleanup(func() {
+		fileDownloadOpts.out = ""
+		fileDownloadCmd.SetOut(nil)
+	})
+	return fileDownloadCmd.RunE(fileDownloadCmd, []string{arg})
+}
+
+func TestFileDownloadByDeleteKey(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	content := bytes.Repeat([]byte("0123456789"), 10000)
+	srv := newTestFileServer(t, map[string][]byte{"/f/x1y2": content})
+	ledgerCreated(&cobra.Command{}, ledgerRecord{
+		Kind:      kindFile,
+		URL:       srv.URL + "/f/x1y2",
+		Filename:  "report.csv",
+		DeleteKey: "del-1",
+		SHA256:    sha256Hex(content),
+	})
+
+	// The original name is restored in the output directory.
+	dir := t.TempDir()
+	src, err := resolveDownload("del-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.filename != "report.csv" {
+		t.Errorf("expected the uploaded name, got %q", src.filename)
+	}
+	out := filepath.Join(dir, src.filename)
+	if err := runDownload(t, "del-1", out); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("expected the file content, got error %v", err)
+	}
+	if _, err := os.Stat(out + partExt); !os.IsNotExist(err) {
+		t.Error("expected the part file to be gone")
+	}
+	if err := runDownload(t, "del-1", out); err == nil || !strings.Contains(err.Error(), "exists") {
+		t.Errorf("expected error for an existing file, got %v", err)
+	}
+
+	// Ledger IDs name the same file.
+	l, _ := loadLedger()
+	id := strconv.FormatInt(l.findByDeleteKey("del-1").ID, 10)
+	if err := runDownload(t, id, filepath.Join(dir, "by-id.csv")); err != nil {
+		t.Errorf("download by ledger ID failed: %v", err)
+	}
+}
+
+func TestFileDownloadResumes(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	content := bytes.Repeat([]byte("abcdefgh"), 20000)
+	srv := newTestFileServer(t, map[string][]byte{"/f/big": content})
+	url := srv.URL + "/f/big"
+	ledgerCreated(&cobra.Command{}, ledgerRecord{Kind: kindFile, URL: url, Filename: "big.bin", SHA256: sha256Hex(content)})
+
+	// A part left by an earlier run is continued with a Range request.
+	out := filepath.Join(t.TempDir(), "big.bin")
+	writeTestPart(t, out+partExt, content[:1000], partMeta{URL: url, Validator: `"v1"`, Size: int64(len(content))})
+	if err := runDownload(t, url, out); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if got, _ := os.ReadFile(out); !bytes.Equal(got, content) {
+		t.Error("expected the resumed file to match")
+	}
+	if len(srv.ranges) != 1 || srv.ranges[0] != "bytes=1000-" {
+		t.Errorf("expected one request for bytes=1000-, got %q", srv.ranges)
+	}
+
+	// A download cut off halfway is resumed within the command.
+	srv.ranges, srv.cutFirst = nil, true
+	prev := rootOpts.retries
+	rootOpts.retries = 2
+	defer func() { rootOpts.retries = prev }()
+	out2 := filepath.Join(t.TempDir(), "big.bin")
+	if err := runDownload(t, url, out2); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if got, _ := os.ReadFile(out2); !bytes.Equal(got, content) {
+		t.Error("expected the file cut off and resumed to match")
+	}
+	if len(srv.ranges) != 2 || srv.ranges[0] != "" || !strings.HasPrefix(srv.ranges[1], "bytes=") {
+		t.Errorf("expected a full request then a range, got %q", srv.ranges)
+	}
+}
+
+func TestFileDownloadChecksumMismatch(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	srv := newTestFileServer(t, map[string][]byte{"/f/x": []byte("tampered")})
+	url := srv.URL + "/f/x"
+	ledgerCreated(&cobra.Command{}, ledgerRecord{Kind: kindFile, URL: url, Filename: "x.txt", SHA256: sha256Hex([]byte("original"))})
+
+	out := filepath.Join(t.TempDir(), "x.txt")
+	err := runDownload(t, url, out)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+	for _, p := range []string{out, out + partExt} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", p)
+		}
+	}
+}
+
+func TestFileDownloadDecrypts(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	key, _ := newKey()
+	plain := []byte("customer logs")
+	enc := encryptTestBytes(t, key, plain)
+	srv := newTestFileServer(t, map[string][]byte{"/f/e": enc})
+	url := srv.URL + "/f/e"
+	ledgerCreated(&cobra.Command{}, ledgerRecord{Kind: kindFile, URL: url, Filename: "logs.txt.enc", SHA256: sha256Hex(enc), Encrypted: true})
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "logs.txt")
+	if err := runDownload(t, url+"#"+encodeKey(key), out); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if got, _ := os.ReadFile(out); !bytes.Equal(got, plain) {
+		t.Errorf("expected the decrypted file, got %q", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only the decrypted file, got %d files", len(entries))
+	}
+}
+
+// writeTestPart leaves a part with content and its record, as an
+// interrupted run would.
+func writeTestPart(t *testing.T, part string, content []byte, meta partMeta) {
+	t.Helper()
+	if err := os.WriteFile(part, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePartMeta(part, meta); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileDownloadStalePart(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	content := bytes.Repeat([]byte("new version "), 1000)
+	srv := newTestFileServer(t, map[string][]byte{"/f/doc": content})
+	srv.etag = `"v2"`
+	url := srv.URL + "/f/doc"
+	stale := bytes.Repeat([]byte("old version "), 500)
+
+	tests := map[string]struct {
+		part []byte
+		meta *partMeta
+	}{
+		// Another file saved under the same name, without a record.
+		"no record": {stale, nil},
+		"other url": {stale, &partMeta{URL: srv.URL + "/f/other", Validator: `"v2"`, Size: int64(len(content))}},
+		// The file changed since: If-Range gets the whole new file.
+		"changed": {stale, &partMeta{URL: url, Validator: `"v1"`, Size: int64(len(content))}},
+		// A part longer than the file gets a 416, which is not taken as done.
+		"too long": {append(bytes.Clone(content), stale...), &partMeta{URL: url, Validator: `"v2"`, Size: -1}},
+	}
+	for name, tt := range tests {
+		out := filepath.Join(t.TempDir(), "doc.txt")
+		if tt.meta != nil {
+			writeTestPart(t, out+partExt, tt.part, *tt.meta)
+		} else if err := os.WriteFile(out+partExt, tt.part, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fileDownloadCmd.SetErr(io.Discard)
+		if err := runDownload(t, url, out); err != nil {
+			t.Fatalf("%s: download failed: %v", name, err)
+		}
+		fileDownloadCmd.SetErr(nil)
+		if got, _ := os.ReadFile(out); !bytes.Equal(got, content) {
+			t.Errorf("%s: expected the new file alone, got %d bytes", name, len(got))
+		}
+		if _, err := os.Stat(out + partExt + partMetaExt); !os.IsNotExist(err) {
+			t.Errorf("%s: expected the part record to be removed", name)
+		}
+	}
+}
+
+func TestFileDownloadNumericDeleteKey(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	pages := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		if r.URL.Query().Get("page") == "" {
+			io.WriteString(w, `{"code":200,"data":[{"delete":"12345","filename":"a.txt","url":"https://f.example/a"}]}`)
+			return
+		}
+		io.WriteString(w, `{"code":200,"data":[{"delete":"other","filename":"b.txt","url":"https://f.example/b"}]}`)
+	}))
+	defer srv.Close()
+	prev := apiClient
+	apiClient = seesdk.NewClient(seesdk.Config{BaseURL: srv.URL, APIKey: "k"})
+	defer func() { apiClient = prev }()
+
+	// A number that is no ledger ID is looked up as a delete key.
+	src, err := resolveDownload("12345")
+	if err != nil || src.url != "https://f.example/a" {
+		t.Fatalf("expected the file from the history, got %+v, %v", src, err)
+	}
+
+	// The history is not searched without end.
+	pages = 0
+	if _, err := resolveDownload("missing"); err == nil || !strings.Contains(err.Error(), "pages") {
+		t.Errorf("expected the search to stop, got %v", err)
+	}
+	if pages != maxHistoryPages {
+		t.Errorf("expected %d pages, got %d", maxHistoryPages, pages)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := map[string][2]int64{"bytes 100-199/200": {100, 200}, "bytes 0-0/1": {0, 1}, "bytes 5-9/*": {5, -1}}
+	for v, want := range tests {
+		if start, total, ok := parseContentRange(v); !ok || start != want[0] || total != want[1] {
+			t.Errorf("parseContentRange(%q) = %d, %d, %v; want %v", v, start, total, ok, want)
+		}
+	}
+	for _, v := range []string{"", "bytes */200", "items 1-2/3", "bytes 1-2"} {
+		if _, _, ok := parseContentRange(v); ok {
+			t.Errorf("parseContentRange(%q): expected failure", v)
+		}
+	}
+}
+
+func TestSafeFilename(t *testing.T) {
+	tests := map[string]string{"report.csv": "report.csv", "../../etc/passwd": "passwd", "": "download", "/": "download"}
+	for in, want := range tests {
+		if got := safeFilename(in); got != want {
+			t.Errorf("safeFilename(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_upload.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:05:52
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:16:07
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// Statuses of a file in the summary of a multi-file upload.
+const (
+	uploadOK      = "uploaded"
+	uploadFailed  = "failed"
+	uploadSkipped = "skipped"
+)
+
+// uploadRow is one line of the summary of a multi-file upload.
+type uploadRow struct {
+	File string `json:"file"`
+	// Path is the path below an uploaded directory, so that the summary
+	// maps a directory tree to URLs
+	Path   string `json:"path,omitempty"`
+	Size   int64  `json:"size"`
+	URL    string `json:"url,omitempty"`
+	Delete string `json:"delete,omitempty"`
+	Page   string `json:"page,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Key is the key of encrypted uploads, the same for every file
+	Key string `json:"key,omitempty"`
+}
+
+// uploadFiles uploads inputs on the batch worker pool and prints a summary
+// of all of them at the end. A failed file does not stop the others unless
+// --fail-fast is set; files that were never started are reported as skipped.
+func uploadFiles(cmd *cobra.Command, inputs []uploadInput) error {
+	rows := make([]uploadRow, len(inputs))
+	for i, in := range inputs {
+		rows[i] = uploadRow{File: in.path, Path: in.rel, Status: uploadSkipped}
+	}
+	var total int64
+	for _, in := range inputs {
+		fi, err := os.Stat(in.path)
+		if err != nil || total < 0 {
+			total = -1
+			continue
+		}
+		if fileUploadOpts.key != nil {
+			total += encryptedSize(fi.Size())
+		} else {
+			total += fi.Size()
+		}
+	}
+	p := newProgress(cmd, total)
+	failed := 0
+	runBatch(fileUploadOpts.batch, len(inputs), func(i int) uploadRow {
+		return uploadPath(cmd, inputs[i], p)
+	}, func(i int, r uploadRow) bool {
+		rows[i] = r
+		if r.Status != uploadFailed {
+			return true
+		}
+		failed++
+		return !fileUploadOpts.failFast
+	})
+	p.close()
+
+	if err := render(cmd, result{
+		data:    rows,
+		columns: []string{"file", "size", "url", "delete", "status"},
+		text: func(w io.Writer) error {
+			return writeUploadSummary(w, rows)
+		},
+	}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d uploads failed", failed, len(inputs))
+	}
+	return nil
+}
+
+// uploadPath uploads the file of in under its base name.
+func uploadPath(cmd *cobra.Command, in uploadInput, p *progress) uploadRow {
+	row := uploadRow{File: in.path, Path: in.rel, Status: uploadFailed}
+	f, err := os.Open(in.path)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	defer f.Close()
+	if fi, err := f.Stat(); err == nil {
+		row.Size = fi.Size()
+	}
+	resp, err := uploadFile(cmd, filepath.Base(in.path), f, p)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.URL = resp.Data.URL
+	row.Delete = resp.Data.Delete
+	row.Page = resp.Data.Page
+	row.Key = uploadKey()
+	row.Status = uploadOK
+	return row
+}
+
+// writeUploadSummary prints the summary table followed by the errors of the
+// failed files.
+func writeUploadSummary(out io.Writer, rows []uploadRow) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tSIZE\tURL\tDELETE KEY\tSTATUS")
+	counts := map[string]int{}
+	for _, r := range rows {
+		counts[r.Status]++
+		size := ""
+		if r.Size > 0 || r.Status == uploadOK {
+			size = formatSize(r.Size)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.File, size, r.URL, r.Delete, r.Status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if r.Error != "" {
+			fmt.Fprintf(out, "%s: %s\n", r.File, r.Error)
+		}
+	}
+	fmt.Fprintf(out, "\n%d uploaded, %d failed, %d skipped\n", counts[uploadOK], counts[uploadFailed], counts[uploadSkipped])
+	if key := uploadKey(); key != "" && counts[uploadOK] > 0 {
+		fmt.Fprintf(out, "Key: %s\n", key)
+	}
+	return nil
+}
+
+// formatSize renders a byte count for humans.
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
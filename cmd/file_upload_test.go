@@ -0,0 +1,158 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_upload_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:05:52
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:08:49
+//
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+// withTestUploadServer points apiClient at a server that accepts every
+// upload except those of files named fail.txt.
+func withTestUploadServer(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		if err != nil || header.Filename == "fail.txt" {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"code":400,"message":"rejected"}`)
+			return
+		}
+		io.WriteString(w, `{"code":200,"data":{"url":"https://f.example/`+header.Filename+`","delete":"key-`+header.Filename+`","page":"p"}}`)
+	}))
+	t.Cleanup(srv.Close)
+	prev := apiClient
+	apiClient = seesdk.NewClient(seesdk.Config{BaseURL: srv.URL, APIKey: "k"})
+	t.Cleanup(func() { apiClient = prev })
+}
+
+func writeTestFiles(t *testing.T, names ...string) []uploadInput {
+	t.Helper()
+	dir := t.TempDir()
+	var inputs []uploadInput
+	for _, name := range names {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("content of "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, uploadInput{path: p})
+	}
+	return inputs
+}
+
+func TestUploadFiles(t *testing.T) {
+	withTestUploadServer(t)
+	paths := writeTestFiles(t, "a.txt", "fail.txt", "b.txt")
+	paths = append(paths, uploadInput{path: filepath.Join(t.TempDir(), "missing.txt")})
+	fileUploadOpts.batch = batchOpts{concurrency: 2}
+	fileUploadOpts.failFast = false
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	err := uploadFiles(cmd, paths)
+	if err == nil || err.Error() != "2 of 4 uploads failed" {
+		t.Errorf("expected 2 failures, got %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"https://f.example/a.txt", "key-b.txt", "16 B", "rejected", "2 uploaded, 2 failed, 0 skipped"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected summary to contain %q:\n%s", want, got)
+		}
+	}
+	// Rows stay in the order the files were given.
+	if strings.Index(got, "a.txt") > strings.Index(got, "fail.txt") || strings.Index(got, "fail.txt") > strings.Index(got, "b.txt") {
+		t.Errorf("expected rows in input order:\n%s", got)
+	}
+}
+
+func TestUploadFilesFailFast(t *testing.T) {
+	withTestUploadServer(t)
+	paths := writeTestFiles(t, "a.txt", "fail.txt", "b.txt", "c.txt")
+	fileUploadOpts.batch = batchOpts{concurrency: 1}
+	fileUploadOpts.failFast = true
+	defer func() { fileUploadOpts.failFast = false }()
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	if err := uploadFiles(cmd, paths); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if !strings.Contains(out.String(), "1 uploaded, 1 failed, 2 skipped") {
+		t.Errorf("expected the rest to be skipped:\n%s", out.String())
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KiB", 5 << 20: "5.0 MiB", 3 << 30: "3.0 GiB"}
+	for n, want := range tests {
+		if got := formatSize(n); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestUploadFileTooLarge(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer srv.Close()
+	prev := apiClient
+	apiClient = seesdk.NewClient(seesdk.Config{BaseURL: srv.URL, APIKey: "k"})
+	defer func() { apiClient = prev }()
+
+	// A sparse file takes no space on disk.
+	path := filepath.Join(t.TempDir(), "big.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := f.Truncate(maxUploadSize + 1); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, encrypt := range []bool{false, true} {
+		fileUploadOpts.key = nil
+		if encrypt {
+			fileUploadOpts.key, _ = newKey()
+		}
+		f.Seek(0, io.SeekStart)
+		p := newProgress(&cobra.Command{}, -1)
+		_, err := uploadFile(&cobra.Command{}, "big.bin", f, p)
+		p.close()
+		if err == nil || !strings.Contains(err.Error(), "upload limit") {
+			t.Errorf("encrypt=%v: expected a size error, got %v", encrypt, err)
+		}
+	}
+	fileUploadOpts.key = nil
+	if requests != 0 {
+		t.Errorf("expected no request, got %d", requests)
+	}
+}
@@ -0,0 +1,258 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_walk.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:08:49
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:08:49
+//
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// seeIgnoreFile is the name of the files holding ignore rules for recursive
+// uploads, read from every directory walked.
+const seeIgnoreFile = ".seeignore"
+
+// uploadInput is a file to upload. rel is its slash-separated path below the
+// directory given on the command line, or empty for files given directly.
+type uploadInput struct {
+	path string
+	rel  string
+}
+
+// walkOpts selects the files of a recursive upload.
+type walkOpts struct {
+	include        []string
+	exclude        []string
+	hidden         bool
+	followSymlinks bool
+}
+
+// expandUploadPaths turns the paths given on the command line into the files
+// to upload. Directories are walked when recursive is set and rejected
+// otherwise.
+func expandUploadPaths(paths []string, recursive bool, opts walkOpts) ([]uploadInput, error) {
+	for _, patterns := range [][]string{opts.include, opts.exclude} {
+		for _, p := range patterns {
+			if _, err := path.Match(strings.ReplaceAll(p, "**", "*"), ""); err != nil {
+				return nil, fmt.Errorf("invalid glob %q: %w", p, err)
+			}
+		}
+	}
+	var inputs []uploadInput
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil || !fi.IsDir() {
+			// Missing files are reported by the upload itself.
+			inputs = append(inputs, uploadInput{path: p})
+			continue
+		}
+		if !recursive {
+			return nil, fmt.Errorf("%s is a directory: use -r to upload its files", p)
+		}
+		found, err := walkUploadDir(p, opts)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, found...)
+	}
+	return inputs, nil
+}
+
+// walkUploadDir returns the files below root that opts and the .seeignore
+// files select, in lexical order.
+func walkUploadDir(root string, opts walkOpts) ([]uploadInput, error) {
+	var (
+		inputs []uploadInput
+		rules  ignoreRules
+	)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return rules.load(p, "")
+		}
+
+		isDir := d.IsDir()
+		if d.Type()&fs.ModeSymlink != 0 {
+			if !opts.followSymlinks {
+				return nil
+			}
+			// Only links to files are followed, so that a link cannot lead
+			// the walk in circles.
+			fi, err := os.Stat(p)
+			if err != nil || !fi.Mode().IsRegular() {
+				return nil
+			}
+		} else if !isDir && !d.Type().IsRegular() {
+			return nil
+		}
+		if !isDir && d.Name() == seeIgnoreFile {
+			return nil
+		}
+
+		skip := rules.ignored(rel, isDir) ||
+			(!opts.hidden && strings.HasPrefix(d.Name(), ".")) ||
+			matchAnyGlob(opts.exclude, rel)
+		if isDir {
+			if skip {
+				return filepath.SkipDir
+			}
+			return rules.load(p, rel)
+		}
+		if skip || (len(opts.include) > 0 && !matchAnyGlob(opts.include, rel)) {
+			return nil
+		}
+		inputs = append(inputs, uploadInput{path: p, rel: rel})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+	return inputs, nil
+}
+
+// ignoreRule is one line of a .seeignore file, which follows the syntax of
+// .gitignore: blank lines and lines starting with # are skipped, ! negates,
+// a trailing / matches directories only, and a pattern containing a / is
+// anchored to the directory of the .seeignore file.
+type ignoreRule struct {
+	// base is the directory of the .seeignore file, relative to the root
+	base     string
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreRules holds the rules of every .seeignore file read so far.
+type ignoreRules []ignoreRule
+
+// load reads the .seeignore file of dir, if there is one. rel is dir
+// relative to the walk root.
+func (rs *ignoreRules) load(dir, rel string) error {
+	f, err := os.Open(filepath.Join(dir, seeIgnoreFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		r := ignoreRule{base: rel}
+		if strings.HasPrefix(line, "!") {
+			r.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		r.anchored = strings.Contains(line, "/")
+		r.pattern = strings.TrimPrefix(line, "/")
+		if r.pattern != "" {
+			*rs = append(*rs, r)
+		}
+	}
+	return sc.Err()
+}
+
+// ignored reports whether the path rel, relative to the walk root, is
+// ignored. The last matching rule wins.
+func (rs ignoreRules) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, r := range rs {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		sub := rel
+		if r.base != "" {
+			if !strings.HasPrefix(rel, r.base+"/") {
+				continue
+			}
+			sub = strings.TrimPrefix(rel, r.base+"/")
+		}
+		var ok bool
+		if r.anchored {
+			ok = matchGlob(r.pattern, sub)
+		} else {
+			ok = matchGlob(r.pattern, path.Base(sub))
+		}
+		if ok {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// matchAnyGlob reports whether rel matches one of patterns. A pattern
+// without a / is matched against the file name, as in .gitignore.
+func matchAnyGlob(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		if !strings.Contains(p, "/") {
+			if matchGlob(p, path.Base(rel)) {
+				return true
+			}
+			continue
+		}
+		if matchGlob(strings.TrimPrefix(p, "/"), rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches a slash-separated path against a glob in which **
+// stands for any number of directories.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,152 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: file_walk_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:08:49
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:08:49
+//
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTestTree creates files with the given slash-separated paths and
+// contents below a temporary directory.
+func writeTestTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func walkedRels(t *testing.T, root string, opts walkOpts) []string {
+	t.Helper()
+	inputs, err := walkUploadDir(root, opts)
+	if err != nil {
+		t.Fatalf("walkUploadDir failed: %v", err)
+	}
+	var rels []string
+	for _, in := range inputs {
+		rels = append(rels, in.rel)
+	}
+	return rels
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.png", "a.png", true},
+		{"*.png", "a.jpg", false},
+		{"shots/*.png", "shots/a.png", true},
+		{"shots/*.png", "shots/x/a.png", false},
+		{"shots/**/*.png", "shots/a.png", true},
+		{"shots/**/*.png", "shots/x/y/a.png", true},
+		{"**/tmp", "a/b/tmp", true},
+		{"build/**", "build/x/y", true},
+		{"build/**", "other/x", false},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWalkUploadDir(t *testing.T) {
+	root := writeTestTree(t, map[string]string{
+		"a.png":             "",
+		"b.txt":             "",
+		".hidden.png":       "",
+		".cache/c.png":      "",
+		"shots/d.png":       "",
+		"shots/raw/e.png":   "",
+		"node_modules/x.js": "",
+	})
+	if err := os.Symlink(filepath.Join(root, "a.png"), filepath.Join(root, "link.png")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	got := walkedRels(t, root, walkOpts{})
+	want := []string{"a.png", "b.txt", "node_modules/x.js", "shots/d.png", "shots/raw/e.png"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("default: expected %v, got %v", want, got)
+	}
+
+	got = walkedRels(t, root, walkOpts{include: []string{"*.png"}, exclude: []string{"raw"}})
+	if want := []string{"a.png", "shots/d.png"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("globs: expected %v, got %v", want, got)
+	}
+
+	got = walkedRels(t, root, walkOpts{include: []string{"shots/**/*.png"}})
+	if want := []string{"shots/d.png", "shots/raw/e.png"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("path glob: expected %v, got %v", want, got)
+	}
+
+	got = walkedRels(t, root, walkOpts{hidden: true, followSymlinks: true, include: []string{"*.png"}})
+	want = []string{".cache/c.png", ".hidden.png", "a.png", "link.png", "shots/d.png", "shots/raw/e.png"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("hidden and symlinks: expected %v, got %v", want, got)
+	}
+}
+
+func TestSeeIgnore(t *testing.T) {
+	root := writeTestTree(t, map[string]string{
+		".seeignore":        "# build output\nnode_modules/\n*.log\n!keep.log\n/draft.txt\n",
+		"a.txt":             "",
+		"draft.txt":         "",
+		"debug.log":         "",
+		"keep.log":          "",
+		"node_modules/x.js": "",
+		"docs/draft.txt":    "",
+		"docs/.seeignore":   "secret.txt\n",
+		"docs/secret.txt":   "",
+		"secret.txt":        "",
+	})
+
+	got := walkedRels(t, root, walkOpts{})
+	want := []string{"a.txt", "docs/draft.txt", "keep.log", "secret.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestExpandUploadPaths(t *testing.T) {
+	root := writeTestTree(t, map[string]string{"sub/a.txt": "", "b.txt": ""})
+	file := filepath.Join(root, "b.txt")
+
+	if _, err := expandUploadPaths([]string{root}, false, walkOpts{}); err == nil {
+		t.Error("expected error for a directory without -r")
+	}
+	inputs, err := expandUploadPaths([]string{file, filepath.Join(root, "sub")}, true, walkOpts{})
+	if err != nil {
+		t.Fatalf("expandUploadPaths failed: %v", err)
+	}
+	want := []uploadInput{{path: file}, {path: filepath.Join(root, "sub", "a.txt"), rel: "a.txt"}}
+	if !reflect.DeepEqual(inputs, want) {
+		t.Errorf("expected %v, got %v", want, inputs)
+	}
+	if _, err := expandUploadPaths([]string{root}, true, walkOpts{include: []string{"[a"}}); err == nil {
+		t.Error("expected error for an invalid glob")
+	}
+}
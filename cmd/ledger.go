@@ -0,0 +1,459 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: ledger.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:30:53
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:19:14
+//
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Kinds of content tracked in the ledger.
+const (
+	kindShortURL = "shorturl"
+	kindText     = "text"
+	kindFile     = "file"
+)
+
+// ledgerRecord describes one piece of content created through the CLI.
+type ledgerRecord struct {
+	ID        int64   `json:"id"`
+	Kind      string  `json:"kind"`
+	Profile   string  `json:"profile,omitempty"`
+	Domain    string  `json:"domain,omitempty"`
+	Slug      string  `json:"slug,omitempty"`
+	URL       string  `json:"url,omitempty"`
+	Target    string  `json:"target,omitempty"`
+	Title     string  `json:"title,omitempty"`
+	TextType  string  `json:"text_type,omitempty"`
+	TagIDs    []int64 `json:"tag_ids,omitempty"`
+	ExpireAt  int64   `json:"expire_at,omitempty"`
+	Filename  string  `json:"filename,omitempty"`
+	Size      int64   `json:"size,omitempty"`
+	DeleteKey string  `json:"delete_key,omitempty"`
+	Page      string  `json:"page,omitempty"`
+	// Files lists the contents of an uploaded archive
+	Files []string `json:"files,omitempty"`
+	// Encrypted is set for content encrypted on this machine; the key is
+	// never stored
+	Encrypted bool `json:"encrypted,omitempty"`
+	// SHA256 is the checksum of an uploaded file as sent
+	SHA256    string     `json:"sha256,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ledgerFile is the on-disk layout of the ledger.
+type ledgerFile struct {
+	NextID  int64          `json:"next_id"`
+	Records []ledgerRecord `json:"records"`
+}
+
+// ledgerMu serializes ledger updates made by concurrent goroutines.
+var ledgerMu sync.Mutex
+
+// dataDir returns the directory holding the CLI's local data.
+func dataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "see"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "see"), nil
+}
+
+// ledgerPath returns the location of the ledger file.
+func ledgerPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ledger.json"), nil
+}
+
+// loadLedger reads the ledger. A missing file yields an empty ledger.
+func loadLedger() (*ledgerFile, error) {
+	l := &ledgerFile{NextID: 1}
+	path, err := ledgerPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, l); err != nil {
+		return nil, fmt.Errorf("invalid ledger %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// updateLedger loads the ledger, applies fn and saves the result.
+func updateLedger(fn func(l *ledgerFile) error) error {
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	l, err := loadLedger()
+	if err != nil {
+		return err
+	}
+	if err := fn(l); err != nil {
+		return err
+	}
+	path, err := ledgerPath()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b, 0600)
+}
+
+// add appends rec with a fresh ID and timestamps.
+func (l *ledgerFile) add(rec ledgerRecord) *ledgerRecord {
+	now := time.Now().UTC()
+	rec.ID = l.NextID
+	rec.CreatedAt, rec.UpdatedAt = now, now
+	l.NextID++
+	l.Records = append(l.Records, rec)
+	return &l.Records[len(l.Records)-1]
+}
+
+// find returns the most recent live record of kind at domain/slug.
+func (l *ledgerFile) find(kind, domain, slug string) *ledgerRecord {
+	for i := len(l.Records) - 1; i >= 0; i-- {
+		r := &l.Records[i]
+		if r.Kind == kind && r.Domain == domain && r.Slug == slug && r.DeletedAt == nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// findByDeleteKey returns the live file record with the given delete key.
+func (l *ledgerFile) findByDeleteKey(key string) *ledgerRecord {
+	for i := len(l.Records) - 1; i >= 0; i-- {
+		r := &l.Records[i]
+		if r.Kind == kindFile && r.DeleteKey == key && r.DeletedAt == nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// findByID returns the record with the given ID.
+func (l *ledgerFile) findByID(id int64) *ledgerRecord {
+	for i := range l.Records {
+		if l.Records[i].ID == id {
+			return &l.Records[i]
+		}
+	}
+	return nil
+}
+
+// findFileByURL returns the live file record with the given URL.
+func (l *ledgerFile) findFileByURL(url string) *ledgerRecord {
+	for i := len(l.Records) - 1; i >= 0; i-- {
+		r := &l.Records[i]
+		if r.Kind == kindFile && r.URL == url && r.DeletedAt == nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// warnLedger reports a ledger failure without failing the command, since the
+// remote operation it describes has already succeeded.
+func warnLedger(cmd *cobra.Command, err error) {
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: could not update local ledger: %v\n", err)
+	}
+}
+
+// ledgerCreated records newly created content.
+func ledgerCreated(cmd *cobra.Command, rec ledgerRecord) {
+	rec.Profile = activeProfile
+	warnLedger(cmd, updateLedger(func(l *ledgerFile) error {
+		l.add(rec)
+		return nil
+	}))
+}
+
+// ledgerUpdated applies fn to the record of kind at domain/slug. Content
+// that was not created through the CLI is adopted into the ledger.
+func ledgerUpdated(cmd *cobra.Command, kind, domain, slug string, fn func(r *ledgerRecord)) {
+	warnLedger(cmd, updateLedger(func(l *ledgerFile) error {
+		r := l.find(kind, domain, slug)
+		if r == nil {
+			r = l.add(ledgerRecord{Kind: kind, Profile: activeProfile, Domain: domain, Slug: slug})
+		}
+		fn(r)
+		r.UpdatedAt = time.Now().UTC()
+		return nil
+	}))
+}
+
+// ledgerDeleted marks the record of kind at domain/slug as deleted.
+func ledgerDeleted(cmd *cobra.Command, kind, domain, slug string) {
+	warnLedger(cmd, updateLedger(func(l *ledgerFile) error {
+		if r := l.find(kind, domain, slug); r != nil {
+			markDeleted(r)
+		}
+		return nil
+	}))
+}
+
+// ledgerFileDeleted marks the file record with the given delete key as deleted.
+func ledgerFileDeleted(cmd *cobra.Command, deleteKey string) {
+	warnLedger(cmd, updateLedger(func(l *ledgerFile) error {
+		if r := l.findByDeleteKey(deleteKey); r != nil {
+			markDeleted(r)
+		}
+		return nil
+	}))
+}
+
+// ledgerFileUpdated applies fn to the live file record with deleteKey.
+func ledgerFileUpdated(cmd *cobra.Command, deleteKey string, fn func(r *ledgerRecord)) {
+	warnLedger(cmd, updateLedger(func(l *ledgerFile) error {
+		if r := l.findByDeleteKey(deleteKey); r != nil {
+			fn(r)
+			r.UpdatedAt = time.Now().UTC()
+		}
+		return nil
+	}))
+}
+
+func markDeleted(r *ledgerRecord) {
+	now := time.Now().UTC()
+	r.DeletedAt = &now
+	r.UpdatedAt = now
+}
+
+// ledgerFilter holds the filter and sort options of the ledger list commands.
+type ledgerFilter struct {
+	domain         string
+	tags           []string
+	tagIDs         []int64
+	createdBefore  string
+	createdAfter   string
+	expiringWithin time.Duration
+	search         string
+	sortBy         string
+	reverse        bool
+	all            bool
+	limit          int
+	// profile limits the records to those created with this profile,
+	// unless allProfiles is set
+	profile     string
+	allProfiles bool
+}
+
+// addLedgerFilterFlags registers the filter and sort flags shared by the
+// ledger list commands.
+func addLedgerFilterFlags(cmd *cobra.Command, f *ledgerFilter) {
+	cmd.Flags().StringVar(&f.domain, "domain", "", "Only show entries on this domain")
+	cmd.Flags().StringSliceVar(&f.tags, "tag", nil, "Only show entries carrying any of these tags (names or IDs, comma-separated)")
+	cmd.RegisterFlagCompletionFunc("tag", completeTags)
+	cmd.Flags().Int64SliceVar(&f.tagIDs, "tag-ids", nil, "Only show entries carrying any of these tag IDs")
+	cmd.RegisterFlagCompletionFunc("tag-ids", completeTagIDs)
+	cmd.Flags().StringVar(&f.createdBefore, "created-before", "", "Only show entries created before this date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&f.createdAfter, "created-after", "", "Only show entries created after this date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().Var((*relDurationValue)(&f.expiringWithin), "expiring-within", "Only show entries expiring within this duration (e.g. 72h or 7d)")
+	cmd.Flags().StringVar(&f.search, "search", "", "Only show entries whose title or target contains this text")
+	cmd.Flags().StringVar(&f.sortBy, "sort", "created", "Sort by created, updated, expire, slug, title or target")
+	cmd.Flags().BoolVar(&f.reverse, "reverse", false, "Reverse the sort order")
+	cmd.Flags().BoolVar(&f.all, "all", false, "Include deleted entries")
+	cmd.Flags().BoolVar(&f.allProfiles, "all-profiles", false, "Include entries created with other profiles")
+	cmd.Flags().IntVar(&f.limit, "limit", 0, "Show at most this many entries (0 = no limit)")
+}
+
+// apply returns the records of kind that pass the filter, sorted.
+func (f *ledgerFilter) apply(records []ledgerRecord, kind string, now time.Time) ([]ledgerRecord, error) {
+	var before, after time.Time
+	var err error
+	if f.createdBefore != "" {
+		if before, err = parseDateFlag(f.createdBefore); err != nil {
+			return nil, fmt.Errorf("invalid --created-before: %w", err)
+		}
+	}
+	if f.createdAfter != "" {
+		if after, err = parseDateFlag(f.createdAfter); err != nil {
+			return nil, fmt.Errorf("invalid --created-after: %w", err)
+		}
+	}
+	less, err := ledgerSortFunc(f.sortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	search := strings.ToLower(f.search)
+	out := []ledgerRecord{}
+	for _, r := range records {
+		switch {
+		case r.Kind != kind:
+		case r.DeletedAt != nil && !f.all:
+		case !f.allProfiles && r.Profile != f.profile:
+		case f.domain != "" && r.Domain != f.domain:
+		case len(f.tagIDs) > 0 && !hasAnyTag(r.TagIDs, f.tagIDs):
+		case !before.IsZero() && !r.CreatedAt.Before(before):
+		case !after.IsZero() && !r.CreatedAt.After(after):
+		case f.expiringWithin > 0 && (r.ExpireAt == 0 || r.ExpireAt < now.Unix() || r.ExpireAt > now.Add(f.expiringWithin).Unix()):
+		case search != "" && !strings.Contains(strings.ToLower(r.Title), search) && !strings.Contains(strings.ToLower(r.Target), search):
+		default:
+			out = append(out, r)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if f.reverse {
+			return less(out[j], out[i])
+		}
+		return less(out[i], out[j])
+	})
+	if f.limit > 0 && len(out) > f.limit {
+		out = out[:f.limit]
+	}
+	return out, nil
+}
+
+// ledgerSortFunc returns the ordering for a --sort key.
+func ledgerSortFunc(key string) (func(a, b ledgerRecord) bool, error) {
+	switch key {
+	case "", "created":
+		return func(a, b ledgerRecord) bool { return a.CreatedAt.Before(b.CreatedAt) }, nil
+	case "updated":
+		return func(a, b ledgerRecord) bool { return a.UpdatedAt.Before(b.UpdatedAt) }, nil
+	case "expire":
+		// Entries that never expire sort last.
+		return func(a, b ledgerRecord) bool {
+			if a.ExpireAt == 0 || b.ExpireAt == 0 {
+				return b.ExpireAt == 0 && a.ExpireAt != 0
+			}
+			return a.ExpireAt < b.ExpireAt
+		}, nil
+	case "slug":
+		return func(a, b ledgerRecord) bool { return a.Slug < b.Slug }, nil
+	case "title":
+		return func(a, b ledgerRecord) bool { return a.Title < b.Title }, nil
+	case "target":
+		return func(a, b ledgerRecord) bool { return a.Target < b.Target }, nil
+	default:
+		return nil, fmt.Errorf("invalid --sort %q: use created, updated, expire, slug, title or target", key)
+	}
+}
+
+func hasAnyTag(have, want []int64) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if h == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseDateFlag parses a date given as YYYY-MM-DD (local time) or RFC3339.
+func parseDateFlag(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC3339, got %q", s)
+	}
+	return t, nil
+}
+
+// listLedger prints the ledger records of kind that pass the filter.
+func listLedger(cmd *cobra.Command, kind string, f *ledgerFilter) error {
+	if err := resolveSettings(cmd); err != nil {
+		return err
+	}
+	tagIDs, err := resolveTags(f.tags)
+	if err != nil {
+		return err
+	}
+	filter := *f
+	filter.profile = activeProfile
+	filter.tagIDs = append(append([]int64(nil), f.tagIDs...), tagIDs...)
+	l, err := loadLedger()
+	if err != nil {
+		return err
+	}
+	records, err := filter.apply(l.Records, kind, time.Now())
+	if err != nil {
+		return err
+	}
+	columns := []string{"id", "url", "target", "title", "created_at", "expire_at"}
+	if kind == kindText {
+		columns = []string{"id", "url", "title", "text_type", "created_at", "expire_at"}
+	}
+	return render(cmd, result{
+		data:    records,
+		columns: columns,
+		text: func(out io.Writer) error {
+			w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+			if kind == kindText {
+				fmt.Fprintln(w, "ID\tURL\tTITLE\tTYPE\tCREATED\tEXPIRES")
+			} else {
+				fmt.Fprintln(w, "ID\tURL\tTARGET\tTITLE\tCREATED\tEXPIRES")
+			}
+			for _, r := range records {
+				url := r.URL
+				if url == "" {
+					url = r.Domain + "/" + r.Slug
+				}
+				if r.DeletedAt != nil {
+					url += " (deleted)"
+				}
+				created := r.CreatedAt.Local().Format("2006-01-02 15:04")
+				if kind == kindText {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", r.ID, url, r.Title, r.TextType, created, formatExpireAt(r.ExpireAt))
+				} else {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", r.ID, url, r.Target, r.Title, created, formatExpireAt(r.ExpireAt))
+				}
+			}
+			return w.Flush()
+		},
+	})
+}
+
+// formatExpireAt renders a unix expiry for humans.
+func formatExpireAt(ts int64) string {
+	if ts == 0 {
+		return "never"
+	}
+	return time.Unix(ts, 0).Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,176 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: ledger_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:30:53
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:31:45
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+func TestLedger_Lifecycle(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	cmd := &cobra.Command{}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	ledgerCreated(cmd, ledgerRecord{Kind: kindShortURL, Domain: "s.ee", Slug: "abc", Target: "https://example.com"})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindFile, Filename: "a.png", DeleteKey: "del1"})
+	ledgerUpdated(cmd, kindShortURL, "s.ee", "abc", func(r *ledgerRecord) {
+		r.Target = "https://example.org"
+	})
+	ledgerUpdated(cmd, kindText, "s.ee", "adopted", func(r *ledgerRecord) {
+		r.Title = "adopted"
+	})
+	ledgerFileDeleted(cmd, "del1")
+	if stderr.Len() != 0 {
+		t.Fatalf("unexpected warnings: %s", stderr.String())
+	}
+
+	l, err := loadLedger()
+	if err != nil {
+		t.Fatalf("loadLedger failed: %v", err)
+	}
+	if len(l.Records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(l.Records))
+	}
+
+	r := l.find(kindShortURL, "s.ee", "abc")
+	if r == nil {
+		t.Fatal("short URL record not found")
+	}
+	if r.ID != 1 || r.Target != "https://example.org" {
+		t.Errorf("expected updated record 1, got %+v", r)
+	}
+	if !r.UpdatedAt.After(r.CreatedAt) && !r.UpdatedAt.Equal(r.CreatedAt) {
+		t.Errorf("expected updated_at >= created_at, got %+v", r)
+	}
+	if l.find(kindText, "s.ee", "adopted") == nil {
+		t.Error("expected update of unknown text to adopt it")
+	}
+	if l.findByDeleteKey("del1") != nil {
+		t.Error("expected deleted file to be hidden from lookups")
+	}
+	if l.Records[1].DeletedAt == nil {
+		t.Error("expected deleted_at to be set")
+	}
+
+	ledgerDeleted(cmd, kindShortURL, "s.ee", "abc")
+	l, _ = loadLedger()
+	if l.find(kindShortURL, "s.ee", "abc") != nil {
+		t.Error("expected deleted short URL to be hidden from lookups")
+	}
+	if l.NextID != 4 {
+		t.Errorf("expected next id 4, got %d", l.NextID)
+	}
+}
+
+func TestLedgerFilter_Apply(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	deleted := now.Add(-time.Hour)
+	records := []ledgerRecord{
+		{ID: 1, Kind: kindShortURL, Domain: "s.ee", Slug: "b", Title: "Spring Promo", TagIDs: []int64{1}, ExpireAt: now.Add(24 * time.Hour).Unix(), CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: 2, Kind: kindShortURL, Domain: "x.link", Slug: "a", Target: "https://blog.example", CreatedAt: now.Add(-24 * time.Hour)},
+		{ID: 3, Kind: kindShortURL, Domain: "s.ee", Slug: "c", CreatedAt: now.Add(-time.Hour), DeletedAt: &deleted},
+		{ID: 4, Kind: kindText, Domain: "s.ee", Slug: "t", CreatedAt: now},
+		{ID: 5, Kind: kindShortURL, Profile: "work", Domain: "s.ee", Slug: "w", CreatedAt: now},
+	}
+
+	tests := []struct {
+		name   string
+		filter ledgerFilter
+		want   []int64
+	}{
+		{"default", ledgerFilter{}, []int64{1, 2}},
+		{"all", ledgerFilter{all: true}, []int64{1, 2, 3}},
+		{"domain", ledgerFilter{domain: "x.link"}, []int64{2}},
+		{"tag", ledgerFilter{tagIDs: []int64{1, 5}}, []int64{1}},
+		{"search title", ledgerFilter{search: "promo"}, []int64{1}},
+		{"search target", ledgerFilter{search: "BLOG"}, []int64{2}},
+		{"expiring", ledgerFilter{expiringWithin: 48 * time.Hour}, []int64{1}},
+		{"not expiring soon", ledgerFilter{expiringWithin: time.Hour}, nil},
+		{"created after", ledgerFilter{createdAfter: "2026-05-31T00:00:00Z"}, []int64{2}},
+		{"sort slug", ledgerFilter{sortBy: "slug"}, []int64{2, 1}},
+		{"reverse limit", ledgerFilter{reverse: true, limit: 1}, []int64{2}},
+		{"profile", ledgerFilter{profile: "work"}, []int64{5}},
+		{"all profiles", ledgerFilter{allProfiles: true}, []int64{1, 2, 5}},
+	}
+	for _, tt := range tests {
+		got, err := tt.filter.apply(records, kindShortURL, now)
+		if err != nil {
+			t.Fatalf("%s: apply failed: %v", tt.name, err)
+		}
+		var ids []int64
+		for _, r := range got {
+			ids = append(ids, r.ID)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, ids)
+		}
+	}
+
+	if _, err := (&ledgerFilter{sortBy: "size"}).apply(records, kindShortURL, now); err == nil {
+		t.Error("expected error for invalid sort key, got nil")
+	}
+	if _, err := (&ledgerFilter{createdBefore: "yesterday"}).apply(records, kindShortURL, now); err == nil {
+		t.Error("expected error for invalid date, got nil")
+	}
+}
+
+func TestListLedger_Tag(t *testing.T) {
+	withTestConfig(t, "")
+	withTestTags(t, seesdk.Tag{ID: 1, Name: "promo"}, seesdk.Tag{ID: 2, Name: "news"})
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	saved := rootOpts
+	defer func() { rootOpts = saved }()
+	rootOpts.output = outputJSON
+
+	cmd := &cobra.Command{}
+	ledgerCreated(cmd, ledgerRecord{Kind: kindText, Domain: "s.ee", Slug: "a", TagIDs: []int64{1}})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindText, Domain: "s.ee", Slug: "b", TagIDs: []int64{2}})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindText, Domain: "s.ee", Slug: "c", TagIDs: []int64{3}})
+
+	tests := []struct {
+		filter ledgerFilter
+		want   string
+	}{
+		{ledgerFilter{tags: []string{"promo"}}, "[a]"},
+		{ledgerFilter{tags: []string{"News", "3"}}, "[b c]"},
+		{ledgerFilter{tags: []string{"promo"}, tagIDs: []int64{3}}, "[a c]"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		if err := listLedger(cmd, kindText, &tt.filter); err != nil {
+			t.Fatalf("%v: listLedger failed: %v", tt.filter.tags, err)
+		}
+		var records []ledgerRecord
+		if err := json.Unmarshal(out.Bytes(), &records); err != nil {
+			t.Fatalf("%v: invalid output %q: %v", tt.filter.tags, out.String(), err)
+		}
+		var slugs []string
+		for _, r := range records {
+			slugs = append(slugs, r.Slug)
+		}
+		if fmt.Sprint(slugs) != tt.want {
+			t.Errorf("%v: expected %s, got %v", tt.filter.tags, tt.want, slugs)
+		}
+	}
+}
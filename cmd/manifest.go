@@ -0,0 +1,542 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: manifest.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:45:13
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:04:38
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Plan actions, in the order they are applied.
+const (
+	actionCreate  = "create"
+	actionUpdate  = "update"
+	actionReplace = "replace"
+	actionDelete  = "delete"
+	// actionSkip marks a link that is not created because it has expired
+	actionSkip = "skip"
+)
+
+// manifestOpts holds options shared by plan and apply
+var manifestOpts struct {
+	file   string
+	domain string
+	prune  bool
+	batch  batchOpts
+	// allowReplace lets apply delete and recreate links
+	allowReplace bool
+}
+
+// manifest is a YAML file declaring the short URLs that should exist.
+type manifest struct {
+	// Domain is the default domain of links that do not name one
+	Domain string         `yaml:"domain"`
+	Links  []manifestLink `yaml:"links"`
+}
+
+// manifestLink declares one short URL. Password and ExpirationRedirectURL
+// are not recorded in the ledger and are therefore only used on create.
+type manifestLink struct {
+	Slug                  string   `yaml:"slug"`
+	Domain                string   `yaml:"domain"`
+	Target                string   `yaml:"target"`
+	Title                 string   `yaml:"title"`
+	Tags                  []string `yaml:"tags"`
+	ExpireAt              string   `yaml:"expire_at"`
+	Password              string   `yaml:"password"`
+	ExpirationRedirectURL string   `yaml:"expiration_redirect_url"`
+
+	// tagIDs are the resolved Tags
+	tagIDs []int64
+	// expireAt is ExpireAt as unix seconds
+	expireAt int64
+	// expired is set when expireAt is not in the future
+	expired bool
+}
+
+// planChange is one change needed to make the ledger match the manifest.
+type planChange struct {
+	Action  string   `json:"action"`
+	Domain  string   `json:"domain"`
+	Slug    string   `json:"slug"`
+	Target  string   `json:"target,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+
+	link *manifestLink
+	// oldTarget is the target of the link a replace deletes
+	oldTarget string
+}
+
+// shortURLAPI is the part of the API client used by apply.
+type shortURLAPI interface {
+	CreateShortURL(seesdk.CreateShortURLRequest) (*seesdk.CreateShortURLResponse, error)
+	UpdateShortURL(seesdk.UpdateShortURLRequest) (*seesdk.UpdateShortURLResponse, error)
+	DeleteShortURL(seesdk.DeleteURLRequest) (*seesdk.DeleteURLResponse, error)
+}
+
+func init() {
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringVarP(&manifestOpts.file, "file", "f", "", "Manifest file (required)")
+		c.Flags().StringVar(&manifestOpts.domain, "domain", "s.ee", "Domain of links when the manifest names none")
+		bindSetting(c.Flags(), "domain", "domain")
+		c.RegisterFlagCompletionFunc("domain", completeDomains(kindShortURL))
+		c.Flags().BoolVar(&manifestOpts.prune, "prune", false, "Delete links in the ledger that are missing from the manifest")
+		c.MarkFlagRequired("file")
+	}
+	addBatchFlags(applyCmd, &manifestOpts.batch)
+	applyCmd.Flags().BoolVar(&manifestOpts.allowReplace, "allow-replace", false, "Delete and recreate links whose tags or expiry changed")
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes needed to make short URLs match a manifest",
+	Long: `Compare a YAML manifest of short URLs with the local ledger and show the
+links to create, update, replace and, with --prune, delete.
+
+Target and title are updated in place. The API cannot change tags or expiry
+of an existing link, so those changes replace the link: it is deleted and
+created again with the same slug. Replaces are listed apart from the other
+changes, and apply only runs them with --allow-replace. Links whose expire_at
+is in the past are not created or replaced; they are listed as skipped.
+
+With --prune, links of the manifest's domains that are in the ledger for the
+current profile but not in the manifest are deleted.`,
+	Example: `  see plan -f links.yaml
+  see apply -f links.yaml --prune`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveSettings(cmd); err != nil {
+			return err
+		}
+		changes, unmanaged, err := loadPlan()
+		if err != nil {
+			return err
+		}
+		return render(cmd, planResult(changes, unmanaged))
+	},
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create, update and delete short URLs to match a manifest",
+	Long: `Run the changes shown by 'see plan' against the API. Up to --concurrency
+changes run at once; after a failure no further changes are started. Rerun
+apply to continue.
+
+A plan that replaces links is refused unless --allow-replace is given, as a
+replace deletes the link before creating it again.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := manifestOpts.batch.check(); err != nil {
+			return err
+		}
+		changes, _, err := loadPlan()
+		if err != nil {
+			return err
+		}
+		changes, skipped := splitSkipped(changes)
+		applied, applyErr := applyPlan(cmd, apiClient, changes)
+		if err := render(cmd, result{
+			data:    applied,
+			columns: []string{"action", "domain", "slug", "target"},
+			text: func(w io.Writer) error {
+				if len(changes) == 0 {
+					fmt.Fprintln(w, "No changes. The ledger matches the manifest.")
+				} else {
+					writePlan(w, applied)
+					fmt.Fprintf(w, "\nApplied %d of %d changes.\n", len(applied), len(changes))
+				}
+				writeSkipped(w, skipped)
+				return nil
+			},
+		}); err != nil {
+			return err
+		}
+		return applyErr
+	},
+}
+
+// loadPlan reads the manifest and the ledger and computes the plan. It also
+// returns how many links of the manifest's domains are left unmanaged.
+func loadPlan() ([]planChange, int, error) {
+	m, err := loadManifest(manifestOpts.file, manifestOpts.domain)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := resolveManifestTags(m); err != nil {
+		return nil, 0, err
+	}
+	checked := map[string]bool{}
+	for _, link := range m.Links {
+		if checked[link.Domain] {
+			continue
+		}
+		checked[link.Domain] = true
+		if err := checkDomain(kindShortURL, link.Domain); err != nil {
+			return nil, 0, fmt.Errorf("link %s/%s: %w", link.Domain, link.Slug, err)
+		}
+	}
+	l, err := loadLedger()
+	if err != nil {
+		return nil, 0, err
+	}
+	changes, unmanaged := computePlan(m, l.Records, activeProfile, manifestOpts.prune)
+	return changes, unmanaged, nil
+}
+
+// loadManifest reads and validates a manifest. Links without a domain get
+// the manifest's domain, or defaultDomain when it has none.
+func loadManifest(path, defaultDomain string) (*manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest{}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	if m.Domain == "" {
+		m.Domain = defaultDomain
+	}
+
+	seen := map[string]bool{}
+	for i := range m.Links {
+		link := &m.Links[i]
+		if link.Domain == "" {
+			link.Domain = m.Domain
+		}
+		switch {
+		case link.Slug == "":
+			return nil, fmt.Errorf("invalid manifest %s: link %d has no slug", path, i+1)
+		case link.Target == "":
+			return nil, fmt.Errorf("invalid manifest %s: link %s/%s has no target", path, link.Domain, link.Slug)
+		case seen[link.Domain+"/"+link.Slug]:
+			return nil, fmt.Errorf("invalid manifest %s: link %s/%s is declared twice", path, link.Domain, link.Slug)
+		}
+		seen[link.Domain+"/"+link.Slug] = true
+		if link.ExpireAt != "" {
+			// Relative dates such as "tomorrow" would move on every run, so
+			// the links would never match the manifest.
+			t, ok := parseFixedTime(strings.TrimSpace(link.ExpireAt), time.Local)
+			if !ok {
+				return nil, fmt.Errorf("invalid manifest %s: link %s/%s: expire_at %q is not a fixed date: use RFC3339, YYYY-MM-DD [HH:MM] or unix seconds", path, link.Domain, link.Slug, link.ExpireAt)
+			}
+			link.expireAt = t.Unix()
+			link.expired = !t.After(time.Now())
+		}
+	}
+	return m, nil
+}
+
+// resolveManifestTags resolves the tag names of every link.
+func resolveManifestTags(m *manifest) error {
+	for i := range m.Links {
+		link := &m.Links[i]
+		ids, err := resolveTags(link.Tags)
+		if err != nil {
+			return fmt.Errorf("link %s/%s: %w", link.Domain, link.Slug, err)
+		}
+		link.tagIDs = ids
+	}
+	return nil
+}
+
+// computePlan diffs the manifest against the live short URL records of the
+// profile. Deletes are only planned with prune; otherwise their number is
+// returned as unmanaged.
+func computePlan(m *manifest, records []ledgerRecord, profile string, prune bool) ([]planChange, int) {
+	current := map[string]ledgerRecord{}
+	for _, r := range records {
+		if r.Kind == kindShortURL && r.DeletedAt == nil && r.Profile == profile {
+			current[r.Domain+"/"+r.Slug] = r
+		}
+	}
+
+	var changes []planChange
+	domains := map[string]bool{}
+	declared := map[string]bool{}
+	for i := range m.Links {
+		link := &m.Links[i]
+		key := link.Domain + "/" + link.Slug
+		domains[link.Domain] = true
+		declared[key] = true
+
+		c := planChange{Domain: link.Domain, Slug: link.Slug, Target: link.Target, link: link}
+		r, ok := current[key]
+		if !ok {
+			c.Action = actionCreate
+			changes = append(changes, skipExpired(c))
+			continue
+		}
+		if r.Target != link.Target {
+			c.Action = actionUpdate
+			c.Changes = append(c.Changes, fmt.Sprintf("target: %q -> %q", r.Target, link.Target))
+		}
+		if r.Title != link.Title {
+			c.Action = actionUpdate
+			c.Changes = append(c.Changes, fmt.Sprintf("title: %q -> %q", r.Title, link.Title))
+		}
+		if !sameTags(r.TagIDs, link.tagIDs) {
+			c.Action = actionReplace
+			c.Changes = append(c.Changes, fmt.Sprintf("tag_ids: %v -> %v", r.TagIDs, link.tagIDs))
+		}
+		if r.ExpireAt != link.expireAt {
+			c.Action = actionReplace
+			c.Changes = append(c.Changes, fmt.Sprintf("expire_at: %s -> %s", formatExpireAt(r.ExpireAt), formatExpireAt(link.expireAt)))
+		}
+		if c.Action == actionReplace {
+			c.oldTarget = r.Target
+		}
+		if c.Action != "" {
+			changes = append(changes, skipExpired(c))
+		}
+	}
+
+	var deletes []planChange
+	for key, r := range current {
+		if declared[key] || !domains[r.Domain] {
+			continue
+		}
+		deletes = append(deletes, planChange{Action: actionDelete, Domain: r.Domain, Slug: r.Slug, Target: r.Target})
+	}
+	if !prune {
+		return changes, len(deletes)
+	}
+	sort.Slice(deletes, func(i, j int) bool {
+		if deletes[i].Domain != deletes[j].Domain {
+			return deletes[i].Domain < deletes[j].Domain
+		}
+		return deletes[i].Slug < deletes[j].Slug
+	})
+	return append(changes, deletes...), 0
+}
+
+// skipExpired turns the create or replace of an expired link into a skip,
+// as it would only create a link that no longer works. Links that only need
+// an update are still updated.
+func skipExpired(c planChange) planChange {
+	if !c.link.expired || (c.Action != actionCreate && c.Action != actionReplace) {
+		return c
+	}
+	c.Action = actionSkip
+	c.Changes = append(c.Changes, fmt.Sprintf("expire_at %s is in the past", formatExpireAt(c.link.expireAt)))
+	return c
+}
+
+// splitSkipped separates the skipped links from the changes to run.
+func splitSkipped(changes []planChange) (run, skipped []planChange) {
+	for _, c := range changes {
+		if c.Action == actionSkip {
+			skipped = append(skipped, c)
+		} else {
+			run = append(run, c)
+		}
+	}
+	return run, skipped
+}
+
+// sameTags reports whether two tag ID lists hold the same IDs.
+func sameTags(a, b []int64) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// applyPlan runs the changes on the batch worker pool and returns those that
+// succeeded, in plan order. No further changes are started after a failure.
+// A plan with replaces is refused without --allow-replace.
+func applyPlan(cmd *cobra.Command, api shortURLAPI, changes []planChange) ([]planChange, error) {
+	applied := []planChange{}
+	changes, _ = splitSkipped(changes)
+	if !manifestOpts.allowReplace {
+		var replaces []string
+		for _, c := range changes {
+			if c.Action == actionReplace {
+				replaces = append(replaces, c.Domain+"/"+c.Slug)
+			}
+		}
+		if len(replaces) > 0 {
+			return applied, fmt.Errorf("the plan replaces %s, which deletes and recreates them to change tags or expiry: rerun with --allow-replace", strings.Join(replaces, ", "))
+		}
+	}
+	var firstErr error
+	runBatch(manifestOpts.batch, len(changes), func(i int) error {
+		return applyChange(cmd, api, changes[i])
+	}, func(i int, err error) bool {
+		c := changes[i]
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s %s/%s: %w", c.Action, c.Domain, c.Slug, err)
+			}
+			return false
+		}
+		applied = append(applied, c)
+		return true
+	})
+	return applied, firstErr
+}
+
+// applyChange runs a single change of a plan.
+func applyChange(cmd *cobra.Command, api shortURLAPI, c planChange) error {
+	switch c.Action {
+	case actionCreate:
+		return applyCreate(cmd, api, c.link)
+	case actionUpdate:
+		_, err := api.UpdateShortURL(seesdk.UpdateShortURLRequest{
+			Domain:    c.Domain,
+			Slug:      c.Slug,
+			TargetURL: c.link.Target,
+			Title:     c.link.Title,
+		})
+		if err == nil {
+			ledgerUpdated(cmd, kindShortURL, c.Domain, c.Slug, func(r *ledgerRecord) {
+				r.Target = c.link.Target
+				r.Title = c.link.Title
+			})
+		}
+		return err
+	case actionReplace, actionDelete:
+		_, err := api.DeleteShortURL(seesdk.DeleteURLRequest{Domain: c.Domain, Slug: c.Slug})
+		if err != nil {
+			return err
+		}
+		ledgerDeleted(cmd, kindShortURL, c.Domain, c.Slug)
+		if c.Action == actionReplace {
+			if err := applyCreate(cmd, api, c.link); err != nil {
+				return fmt.Errorf("the old link was deleted but not created again (its target was %s); rerun apply to create it: %w", c.oldTarget, err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyCreate creates the short URL of a manifest link.
+func applyCreate(cmd *cobra.Command, api shortURLAPI, link *manifestLink) error {
+	req := seesdk.CreateShortURLRequest{
+		TargetURL:             link.Target,
+		Domain:                link.Domain,
+		CustomSlug:            link.Slug,
+		Title:                 link.Title,
+		Password:              link.Password,
+		ExpireAt:              link.expireAt,
+		TagIDs:                link.tagIDs,
+		ExpirationRedirectURL: link.ExpirationRedirectURL,
+	}
+	resp, err := api.CreateShortURL(req)
+	if err != nil {
+		return err
+	}
+	ledgerCreated(cmd, ledgerRecord{
+		Kind:     kindShortURL,
+		Domain:   req.Domain,
+		Slug:     resp.Data.Slug,
+		URL:      resp.Data.ShortURL,
+		Target:   req.TargetURL,
+		Title:    req.Title,
+		TagIDs:   req.TagIDs,
+		ExpireAt: req.ExpireAt,
+	})
+	return nil
+}
+
+// planResult renders a plan.
+func planResult(changes []planChange, unmanaged int) result {
+	if changes == nil {
+		changes = []planChange{}
+	}
+	return result{
+		data:    changes,
+		columns: []string{"action", "domain", "slug", "target"},
+		text: func(w io.Writer) error {
+			run, skipped := splitSkipped(changes)
+			if len(run) == 0 {
+				fmt.Fprintln(w, "No changes. The ledger matches the manifest.")
+			} else {
+				var replaces, others []planChange
+				for _, c := range run {
+					if c.Action == actionReplace {
+						replaces = append(replaces, c)
+					} else {
+						others = append(others, c)
+					}
+				}
+				writePlan(w, others)
+				if len(replaces) > 0 {
+					if len(others) > 0 {
+						fmt.Fprintln(w)
+					}
+					fmt.Fprintln(w, "These links are deleted and created again, which apply only does with --allow-replace:")
+					writePlan(w, replaces)
+				}
+				counts := map[string]int{}
+				for _, c := range run {
+					counts[c.Action]++
+				}
+				fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to replace, %d to delete.\n",
+					counts[actionCreate], counts[actionUpdate], counts[actionReplace], counts[actionDelete])
+			}
+			writeSkipped(w, skipped)
+			if unmanaged > 0 {
+				fmt.Fprintf(w, "%d link(s) in the ledger are not in the manifest; use --prune to delete them.\n", unmanaged)
+			}
+			return nil
+		},
+	}
+}
+
+// writeSkipped lists the links left out of the plan because they expired.
+func writeSkipped(w io.Writer, skipped []planChange) {
+	if len(skipped) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nThese links are skipped, as their expire_at is in the past:")
+	writePlan(w, skipped)
+}
+
+// writePlan writes one line per change, followed by the changed fields.
+func writePlan(w io.Writer, changes []planChange) {
+	symbols := map[string]string{
+		actionCreate:  "+",
+		actionUpdate:  "~",
+		actionReplace: "-/+",
+		actionDelete:  "-",
+		actionSkip:    "!",
+	}
+	for _, c := range changes {
+		line := fmt.Sprintf("%s %s %s/%s", symbols[c.Action], c.Action, c.Domain, c.Slug)
+		if c.Action == actionCreate {
+			line += " -> " + c.Target
+		}
+		fmt.Fprintln(w, line)
+		if len(c.Changes) > 0 {
+			fmt.Fprintln(w, "    "+strings.Join(c.Changes, "\n    "))
+		}
+	}
+}
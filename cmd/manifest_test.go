@@ -0,0 +1,253 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: manifest_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:45:13
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:04:38
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+const testManifest = `domain: s.ee
+links:
+  - slug: docs
+    target: https://example.com/docs
+  - slug: blog
+    target: https://example.com/blog-new
+    title: Blog
+  - slug: promo
+    target: https://example.com/promo
+    tags: [promo, 2]
+    expire_at: 2100-01-01T00:00:00Z
+  - slug: same
+    domain: x.link
+    target: https://example.com/same
+`
+
+// fakeShortURLAPI records the calls made by apply.
+type fakeShortURLAPI struct {
+	calls []string
+	fail  string
+}
+
+func (f *fakeShortURLAPI) call(name, slug string) error {
+	f.calls = append(f.calls, name+" "+slug)
+	if f.fail == name+" "+slug {
+		return errors.New("API error (status 500): boom")
+	}
+	return nil
+}
+
+func (f *fakeShortURLAPI) CreateShortURL(req seesdk.CreateShortURLRequest) (*seesdk.CreateShortURLResponse, error) {
+	if err := f.call("create", req.CustomSlug); err != nil {
+		return nil, err
+	}
+	resp := &seesdk.CreateShortURLResponse{}
+	resp.Data.Slug = req.CustomSlug
+	resp.Data.ShortURL = "https://" + req.Domain + "/" + req.CustomSlug
+	return resp, nil
+}
+
+func (f *fakeShortURLAPI) UpdateShortURL(req seesdk.UpdateShortURLRequest) (*seesdk.UpdateShortURLResponse, error) {
+	return &seesdk.UpdateShortURLResponse{}, f.call("update", req.Slug)
+}
+
+func (f *fakeShortURLAPI) DeleteShortURL(req seesdk.DeleteURLRequest) (*seesdk.DeleteURLResponse, error) {
+	return &seesdk.DeleteURLResponse{}, f.call("delete", req.Slug)
+}
+
+func writeTestManifest(t *testing.T) *manifest {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "links.yaml")
+	if err := os.WriteFile(path, []byte(testManifest), 0600); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	m, err := loadManifest(path, "default.link")
+	if err != nil {
+		t.Fatalf("loadManifest failed: %v", err)
+	}
+	withTestTags(t, seesdk.Tag{ID: 1, Name: "promo"}, seesdk.Tag{ID: 2, Name: "seasonal"})
+	if err := resolveManifestTags(m); err != nil {
+		t.Fatalf("resolveManifestTags failed: %v", err)
+	}
+	return m
+}
+
+func testLedgerRecords() []ledgerRecord {
+	return []ledgerRecord{
+		{Kind: kindShortURL, Profile: "default", Domain: "s.ee", Slug: "blog", Target: "https://example.com/blog"},
+		{Kind: kindShortURL, Profile: "default", Domain: "s.ee", Slug: "promo", Target: "https://example.com/promo", TagIDs: []int64{1}, ExpireAt: 4102444800},
+		{Kind: kindShortURL, Profile: "default", Domain: "x.link", Slug: "same", Target: "https://example.com/same"},
+		{Kind: kindShortURL, Profile: "default", Domain: "s.ee", Slug: "old", Target: "https://example.com/old"},
+		{Kind: kindShortURL, Profile: "default", Domain: "other.link", Slug: "keep"},
+		{Kind: kindShortURL, Profile: "work", Domain: "s.ee", Slug: "theirs"},
+	}
+}
+
+func TestComputePlan(t *testing.T) {
+	m := writeTestManifest(t)
+
+	changes, unmanaged := computePlan(m, testLedgerRecords(), "default", false)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Action+" "+c.Domain+"/"+c.Slug)
+	}
+	want := []string{"create s.ee/docs", "update s.ee/blog", "replace s.ee/promo"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if len(changes[1].Changes) != 2 {
+		t.Errorf("expected target and title changes, got %v", changes[1].Changes)
+	}
+	if unmanaged != 1 {
+		t.Errorf("expected 1 unmanaged link, got %d", unmanaged)
+	}
+
+	changes, _ = computePlan(m, testLedgerRecords(), "default", true)
+	if last := changes[len(changes)-1]; last.Action != actionDelete || last.Slug != "old" || len(changes) != 4 {
+		t.Errorf("expected only s.ee/old to be pruned, got %+v", changes)
+	}
+}
+
+func TestLoadManifest_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing slug":  "links:\n  - target: https://a\n",
+		"duplicate":     "links:\n  - slug: a\n    target: https://a\n  - slug: a\n    target: https://b\n",
+		"unknown field": "links:\n  - slug: a\n    target: https://a\n    color: red\n",
+		"relative date": "links:\n  - slug: a\n    target: https://a\n    expire_at: tomorrow 18:00\n",
+	}
+	for name, content := range tests {
+		path := filepath.Join(t.TempDir(), "links.yaml")
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write manifest: %v", err)
+		}
+		if _, err := loadManifest(path, "s.ee"); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestComputePlan_Expired(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "links.yaml")
+	content := `links:
+  - slug: gone
+    target: https://example.com/gone
+    expire_at: 978307200
+  - slug: sale
+    target: https://example.com/sale-new
+    expire_at: 978307200
+  - slug: blog
+    target: https://example.com/blog
+    expire_at: 978307200
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	m, err := loadManifest(path, "s.ee")
+	if err != nil {
+		t.Fatalf("expected an expired link to load, got %v", err)
+	}
+	records := []ledgerRecord{
+		{Kind: kindShortURL, Profile: "default", Domain: "s.ee", Slug: "sale", Target: "https://example.com/sale", ExpireAt: 978307200},
+		{Kind: kindShortURL, Profile: "default", Domain: "s.ee", Slug: "blog", Target: "https://example.com/blog"},
+	}
+
+	changes, _ := computePlan(m, records, "default", true)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Action+" "+c.Slug)
+	}
+	want := []string{"skip gone", "update sale", "skip blog"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if last := changes[2].Changes; !strings.Contains(last[len(last)-1], "in the past") {
+		t.Errorf("expected the skip to say why, got %v", last)
+	}
+
+	out := renderWith(t, outputText, nil, planResult(changes, 0))
+	if !strings.Contains(out, "1 to update, 0 to replace") || !strings.Contains(out, "! skip s.ee/gone") {
+		t.Errorf("expected the skips apart from the plan, got:\n%s", out)
+	}
+
+	api := &fakeShortURLAPI{}
+	applied, err := applyPlan(&cobra.Command{}, api, changes)
+	if err != nil || len(applied) != 1 || !reflect.DeepEqual(api.calls, []string{"update sale"}) {
+		t.Errorf("expected only the update to run, got %v, %v and calls %v", applied, err, api.calls)
+	}
+}
+
+func TestApplyPlan(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	activeProfile = "default"
+	// One at a time, so that the calls are in plan order.
+	manifestOpts.batch.concurrency = 1
+	m := writeTestManifest(t)
+	changes, _ := computePlan(m, testLedgerRecords(), "default", true)
+
+	cmd := &cobra.Command{}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	// Replaces need --allow-replace; nothing is applied without it.
+	manifestOpts.allowReplace = false
+	api := &fakeShortURLAPI{}
+	applied, err := applyPlan(cmd, api, changes)
+	if err == nil || !strings.Contains(err.Error(), "--allow-replace") || len(api.calls) != 0 || len(applied) != 0 {
+		t.Fatalf("expected the plan to be refused, got %v and calls %v", err, api.calls)
+	}
+	manifestOpts.allowReplace = true
+	defer func() { manifestOpts.allowReplace = false }()
+
+	api = &fakeShortURLAPI{fail: "create promo"}
+	applied, err = applyPlan(cmd, api, changes)
+	if err == nil || !strings.Contains(err.Error(), "deleted but not created again") || !strings.Contains(err.Error(), "https://example.com/promo") {
+		t.Fatalf("expected an error naming the deleted link, got %v", err)
+	}
+	if len(applied) != 2 {
+		t.Errorf("expected 2 applied changes before the failure, got %d", len(applied))
+	}
+	want := []string{"create docs", "update blog", "delete promo", "create promo"}
+	if !reflect.DeepEqual(api.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, api.calls)
+	}
+
+	// The ledger now reflects what was applied, so a second run only
+	// recreates the link that failed and prunes the stale one.
+	l, err := loadLedger()
+	if err != nil {
+		t.Fatalf("loadLedger failed: %v", err)
+	}
+	changes, _ = computePlan(m, append(testLedgerRecords()[2:4], l.Records...), "default", true)
+	api = &fakeShortURLAPI{}
+	if _, err := applyPlan(cmd, api, changes); err != nil {
+		t.Fatalf("applyPlan failed: %v", err)
+	}
+	want = []string{"create promo", "delete old"}
+	if !reflect.DeepEqual(api.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, api.calls)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("unexpected warnings: %s", stderr.String())
+	}
+}
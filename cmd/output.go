@@ -0,0 +1,341 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: output.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:33:15
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:34:10
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output. The empty format selects the
+// command's own human-readable output.
+const (
+	outputText     = ""
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputCSV      = "csv"
+	outputNDJSON   = "ndjson"
+	outputTemplate = "template="
+)
+
+// result is what a command hands to render.
+type result struct {
+	// data is the value encoded by the structured formats
+	data any
+	// columns are the default columns of the table and csv formats; for a
+	// list of plain values the first column names the values
+	columns []string
+	// text writes the human-readable output; when nil the table is used
+	text func(w io.Writer) error
+}
+
+// messageResult renders an API response that only carries a message, such
+// as the responses of update and delete calls.
+func messageResult(resp any, message string) result {
+	return result{
+		data:    resp,
+		columns: []string{"code", "message"},
+		text: func(w io.Writer) error {
+			if message != "" {
+				fmt.Fprintln(w, message)
+			}
+			return nil
+		},
+	}
+}
+
+// checkOutputFlags validates --output and --query and folds --json into
+// --output.
+func checkOutputFlags() error {
+	if rootOpts.query != "" {
+		if _, err := parseQuery(rootOpts.query); err != nil {
+			return err
+		}
+	}
+	if rootOpts.jsonOutput {
+		if rootOpts.output != outputText && rootOpts.output != outputJSON {
+			return fmt.Errorf("--json conflicts with --output %s", rootOpts.output)
+		}
+		rootOpts.output = outputJSON
+	}
+	switch {
+	case rootOpts.output == outputText,
+		rootOpts.output == outputTable,
+		rootOpts.output == outputJSON,
+		rootOpts.output == outputYAML,
+		rootOpts.output == outputCSV,
+		rootOpts.output == outputNDJSON:
+		return nil
+	case strings.HasPrefix(rootOpts.output, outputTemplate):
+		_, err := parseOutputTemplate()
+		return err
+	default:
+		return fmt.Errorf("invalid --output %q: use table, json, yaml, csv, ndjson or template=<go template>", rootOpts.output)
+	}
+}
+
+// render writes r to the command's stdout in the selected output format.
+func render(cmd *cobra.Command, r result) error {
+	w := cmd.OutOrStdout()
+	if rootOpts.output == outputText && r.text != nil && len(rootOpts.columns) == 0 && rootOpts.query == "" {
+		return r.text(w)
+	}
+
+	value, err := toGeneric(r.data)
+	if err != nil {
+		return err
+	}
+	columns := r.columns
+	if rootOpts.query != "" {
+		q, err := parseQuery(rootOpts.query)
+		if err != nil {
+			return err
+		}
+		if value, err = q.eval(value); err != nil {
+			return fmt.Errorf("--query: %w", err)
+		}
+		if rootOpts.output == outputText && len(rootOpts.columns) == 0 {
+			return printQueryResult(w, value, q.multi)
+		}
+		// The default columns describe the unqueried data.
+		columns = nil
+	}
+	if len(rootOpts.columns) > 0 {
+		columns = rootOpts.columns
+		value = projectColumns(value, columns)
+	}
+
+	switch {
+	case rootOpts.output == outputJSON:
+		return printJSON(w, value)
+	case rootOpts.output == outputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(plainNumbers(value)); err != nil {
+			return err
+		}
+		return enc.Close()
+	case rootOpts.output == outputNDJSON:
+		enc := json.NewEncoder(w)
+		for _, row := range rowsOf(value) {
+			if err := enc.Encode(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	case strings.HasPrefix(rootOpts.output, outputTemplate):
+		tmpl, err := parseOutputTemplate()
+		if err != nil {
+			return err
+		}
+		for _, row := range rowsOf(value) {
+			if err := tmpl.Execute(w, row); err != nil {
+				return err
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+	}
+
+	rows := rowsOf(value)
+	columns = tableColumns(rows, columns)
+	if rootOpts.output == outputCSV {
+		cw := csv.NewWriter(w)
+		cw.Write(columns)
+		for _, row := range rows {
+			cw.Write(rowCells(row, columns))
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(rowCells(row, columns), "\t"))
+	}
+	return tw.Flush()
+}
+
+// parseOutputTemplate compiles the Go template given as template=<text>.
+func parseOutputTemplate() (*template.Template, error) {
+	text := strings.TrimPrefix(rootOpts.output, outputTemplate)
+	tmpl, err := template.New("output").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"join": func(v []any, sep string) string {
+			return strings.Join(rowCells(v, nil), sep)
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// toGeneric converts v to the maps, slices and scalars that its JSON form
+// decodes to, so that every format sees the same field names.
+func toGeneric(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// plainNumbers replaces the json.Number values produced by toGeneric with
+// int64 or float64, which is what encoders other than JSON expect.
+func plainNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = plainNumbers(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = plainNumbers(v[k])
+		}
+	}
+	return v
+}
+
+// rowsOf returns the rows of a list value, or the value itself as one row.
+func rowsOf(v any) []any {
+	if list, ok := v.([]any); ok {
+		return list
+	}
+	if v == nil {
+		return nil
+	}
+	return []any{v}
+}
+
+// tableColumns returns the columns to print: the given ones, or the sorted
+// keys of the first row when none are given.
+func tableColumns(rows []any, columns []string) []string {
+	if len(columns) > 0 {
+		return columns
+	}
+	if len(rows) > 0 {
+		if m, ok := rows[0].(map[string]any); ok {
+			for k := range m {
+				columns = append(columns, k)
+			}
+			sort.Strings(columns)
+			return columns
+		}
+	}
+	return []string{"value"}
+}
+
+// rowCells formats the cells of row for the given columns. Rows that are
+// plain values fill the first column; list rows are formatted element-wise.
+func rowCells(row any, columns []string) []string {
+	if list, ok := row.([]any); ok && columns == nil {
+		cells := make([]string, len(list))
+		for i, v := range list {
+			cells[i] = formatCell(v)
+		}
+		return cells
+	}
+	m, ok := row.(map[string]any)
+	if !ok {
+		return []string{formatCell(row)}
+	}
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		cells[i] = formatCell(lookupColumn(m, c))
+	}
+	return cells
+}
+
+// lookupColumn returns the value of a column, following dots into nested
+// objects (e.g. "data.short_url").
+func lookupColumn(m map[string]any, column string) any {
+	var v any = m
+	for _, part := range strings.Split(column, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = obj[part]
+	}
+	return v
+}
+
+// projectColumns keeps only the given columns of every row.
+func projectColumns(v any, columns []string) any {
+	project := func(row any) any {
+		m, ok := row.(map[string]any)
+		if !ok {
+			return row
+		}
+		out := make(map[string]any, len(columns))
+		for _, c := range columns {
+			out[c] = lookupColumn(m, c)
+		}
+		return out
+	}
+	if list, ok := v.([]any); ok {
+		out := make([]any, len(list))
+		for i, row := range list {
+			out[i] = project(row)
+		}
+		return out
+	}
+	return project(v)
+}
+
+// formatCell renders a single value for the table and csv formats.
+func formatCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any:
+		return strings.Join(rowCells(v, nil), ",")
+	case map[string]any:
+		b, _ := json.Marshal(v)
+		return string(b)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,106 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: output_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:33:15
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:33:15
+//
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+// renderWith renders r with the given --output and --columns values.
+func renderWith(t *testing.T, output string, columns []string, r result) string {
+	t.Helper()
+	saved := rootOpts
+	defer func() { rootOpts = saved }()
+	rootOpts.output, rootOpts.columns, rootOpts.jsonOutput = output, columns, false
+	if err := checkOutputFlags(); err != nil {
+		t.Fatalf("checkOutputFlags(%q) failed: %v", output, err)
+	}
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	if err := render(cmd, r); err != nil {
+		t.Fatalf("render(%q) failed: %v", output, err)
+	}
+	return buf.String()
+}
+
+func TestRender_Formats(t *testing.T) {
+	tags := result{
+		data:    []seesdk.Tag{{ID: 1, Name: "promo"}, {ID: 2, Name: "blog, news"}},
+		columns: []string{"id", "name"},
+		text: func(w io.Writer) error {
+			_, err := io.WriteString(w, "human\n")
+			return err
+		},
+	}
+
+	tests := []struct {
+		output  string
+		columns []string
+		want    string
+	}{
+		{"", nil, "human\n"},
+		{"table", nil, "ID  NAME\n1   promo\n2   blog, news\n"},
+		{"csv", nil, "id,name\n1,promo\n2,\"blog, news\"\n"},
+		{"ndjson", nil, "{\"id\":1,\"name\":\"promo\"}\n{\"id\":2,\"name\":\"blog, news\"}\n"},
+		{"yaml", nil, "- id: 1\n  name: promo\n- id: 2\n  name: blog, news\n"},
+		{"json", []string{"name"}, "[\n  {\n    \"name\": \"promo\"\n  },\n  {\n    \"name\": \"blog, news\"\n  }\n]\n"},
+		{"", []string{"name"}, "NAME\npromo\nblog, news\n"},
+		{"template={{.name}}:{{.id}}", nil, "promo:1\nblog, news:2\n"},
+	}
+	for _, tt := range tests {
+		if got := renderWith(t, tt.output, tt.columns, tags); got != tt.want {
+			t.Errorf("output %q columns %v:\nexpected %q\ngot      %q", tt.output, tt.columns, tt.want, got)
+		}
+	}
+}
+
+func TestRender_PlainValues(t *testing.T) {
+	domains := result{data: []string{"s.ee", "x.link"}, columns: []string{"domain"}}
+	if got := renderWith(t, "csv", nil, domains); got != "domain\ns.ee\nx.link\n" {
+		t.Errorf("unexpected csv: %q", got)
+	}
+	if got := renderWith(t, "", nil, domains); got != "DOMAIN\ns.ee\nx.link\n" {
+		t.Errorf("expected table when no text output is given, got %q", got)
+	}
+}
+
+func TestCheckOutputFlags(t *testing.T) {
+	saved := rootOpts
+	defer func() { rootOpts = saved }()
+
+	rootOpts.output, rootOpts.jsonOutput = "", true
+	if err := checkOutputFlags(); err != nil || rootOpts.output != outputJSON {
+		t.Errorf("expected --json to select json output, got %q, %v", rootOpts.output, err)
+	}
+
+	for _, output := range []string{"xml", "template={{.x"} {
+		rootOpts.output, rootOpts.jsonOutput = output, false
+		if err := checkOutputFlags(); err == nil {
+			t.Errorf("expected error for output %q, got nil", output)
+		}
+	}
+
+	rootOpts.output, rootOpts.jsonOutput = "csv", true
+	if err := checkOutputFlags(); err == nil {
+		t.Error("expected error for --json with --output csv, got nil")
+	}
+}
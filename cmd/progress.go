@@ -0,0 +1,272 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: progress.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:07:22
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:07:22
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	// progressRedraw is how often the bars are redrawn on a terminal.
+	progressRedraw = 200 * time.Millisecond
+
+	// progressLogEvery is how often a progress line is logged when stderr
+	// is not a terminal.
+	progressLogEvery = 10 * time.Second
+
+	// progressBarWidth is the width of a bar in characters.
+	progressBarWidth = 24
+)
+
+// progress reports the bytes sent by uploads on stderr: as bars redrawn in
+// place on a terminal, or as periodic log lines otherwise. A nil *progress
+// reports nothing, so callers need not check whether it is enabled.
+type progress struct {
+	mu    sync.Mutex
+	w     io.Writer
+	tty   bool
+	start time.Time
+	// total is the size of all files, or -1 when a size is unknown
+	total int64
+	files []*progressFile
+	// drawn is the number of lines drawn last time on a terminal
+	drawn int
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// progressFile is the state of one upload.
+type progressFile struct {
+	name     string
+	size     int64 // -1 when unknown, as for stdin
+	sent     int64
+	start    time.Time
+	finished bool
+}
+
+// newProgress returns a progress report for uploads of total bytes, or nil
+// when progress is turned off by --quiet or a machine-readable --output.
+func newProgress(cmd *cobra.Command, total int64) *progress {
+	if rootOpts.quiet || rootOpts.output != outputText {
+		return nil
+	}
+	w := cmd.ErrOrStderr()
+	f, ok := w.(*os.File)
+	p := &progress{
+		w:     w,
+		tty:   ok && term.IsTerminal(int(f.Fd())),
+		start: time.Now(),
+		total: total,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// run redraws or logs the progress until close is called.
+func (p *progress) run() {
+	defer close(p.done)
+	every := progressLogEvery
+	if p.tty {
+		every = progressRedraw
+	}
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.mu.Lock()
+			p.report(time.Now())
+			p.mu.Unlock()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// track returns a reader that counts the bytes read from r as sent for the
+// upload of name. size is -1 when unknown.
+func (p *progress) track(name string, size int64, r io.Reader) *progressReader {
+	pr := &progressReader{r: r, p: p}
+	if p == nil {
+		return pr
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pr.f = &progressFile{name: name, size: size, start: time.Now()}
+	p.files = append(p.files, pr.f)
+	return pr
+}
+
+// finish marks the upload read by pr as done; it leaves the bars. Uploads
+// long enough to have been logged get a last line when logging.
+func (p *progress) finish(pr *progressReader) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pr.f.finished = true
+	if now := time.Now(); !p.tty && now.Sub(pr.f.start) >= progressLogEvery {
+		fmt.Fprintln(p.w, p.line(pr.f, now))
+	}
+}
+
+// close stops reporting and removes the bars from the terminal, so that the
+// output that follows starts on a clean line.
+func (p *progress) close() {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	<-p.done
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clear()
+}
+
+// report draws the bars or logs a line for each running upload.
+func (p *progress) report(now time.Time) {
+	var lines []string
+	for _, f := range p.files {
+		if !f.finished {
+			lines = append(lines, p.line(f, now))
+		}
+	}
+	if len(p.files) > 1 {
+		lines = append(lines, p.overall(now))
+	}
+	if !p.tty {
+		for _, l := range lines {
+			fmt.Fprintln(p.w, l)
+		}
+		return
+	}
+	p.clear()
+	width := 0
+	if f, ok := p.w.(*os.File); ok {
+		width, _, _ = term.GetSize(int(f.Fd()))
+	}
+	for _, l := range lines {
+		if width > 0 && len(l) >= width {
+			l = l[:width-1]
+		}
+		fmt.Fprintln(p.w, l)
+	}
+	p.drawn = len(lines)
+}
+
+// clear removes the lines drawn last time.
+func (p *progress) clear() {
+	if !p.tty || p.drawn == 0 {
+		return
+	}
+	fmt.Fprintf(p.w, "\x1b[%dA", p.drawn)
+	for i := 0; i < p.drawn; i++ {
+		fmt.Fprint(p.w, "\x1b[2K\n")
+	}
+	fmt.Fprintf(p.w, "\x1b[%dA", p.drawn)
+	p.drawn = 0
+}
+
+// line describes the progress of one upload.
+func (p *progress) line(f *progressFile, now time.Time) string {
+	return formatProgress(f.name, f.sent, f.size, now.Sub(f.start), p.tty)
+}
+
+// overall describes the progress of all uploads together.
+func (p *progress) overall(now time.Time) string {
+	var sent int64
+	finished := 0
+	for _, f := range p.files {
+		sent += f.sent
+		if f.finished {
+			finished++
+		}
+	}
+	return formatProgress(fmt.Sprintf("total (%d done)", finished), sent, p.total, now.Sub(p.start), p.tty)
+}
+
+// formatProgress renders "name [bar] sent/size rate ETA". Without a known
+// size there is no bar and no ETA.
+func formatProgress(name string, sent, size int64, elapsed time.Duration, bar bool) string {
+	var b strings.Builder
+	b.WriteString(name)
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(sent) / elapsed.Seconds()
+	}
+	if size < 0 {
+		fmt.Fprintf(&b, "  %s  %s/s", formatSize(sent), formatSize(int64(rate)))
+		return b.String()
+	}
+	pct := 100.0
+	if size > 0 {
+		pct = float64(sent) * 100 / float64(size)
+	}
+	if bar {
+		filled := int(pct / 100 * progressBarWidth)
+		fmt.Fprintf(&b, "  [%s%s]", strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled))
+	}
+	fmt.Fprintf(&b, "  %s / %s (%.0f%%)  %s/s", formatSize(sent), formatSize(size), pct, formatSize(int64(rate)))
+	if sent < size && rate > 0 {
+		eta := time.Duration(float64(size-sent) / rate * float64(time.Second))
+		fmt.Fprintf(&b, "  ETA %s", eta.Round(time.Second))
+	}
+	return b.String()
+}
+
+// progressReader counts the bytes read through it. It can seek when the
+// underlying reader can, so that retryStream can rewind an upload; the
+// count follows the new position.
+type progressReader struct {
+	r io.Reader
+	p *progress
+	f *progressFile
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	if r.f != nil && n > 0 {
+		r.p.mu.Lock()
+		r.f.sent += int64(n)
+		r.p.mu.Unlock()
+	}
+	return n, err
+}
+
+func (r *progressReader) Seek(offset int64, whence int) (int64, error) {
+	s, ok := r.r.(io.Seeker)
+	if !ok {
+		return 0, errors.New("seek not supported")
+	}
+	pos, err := s.Seek(offset, whence)
+	if err == nil && r.f != nil {
+		r.p.mu.Lock()
+		r.f.sent = pos
+		r.p.mu.Unlock()
+	}
+	return pos, err
+}
@@ -0,0 +1,85 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: progress_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:07:22
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:07:22
+//
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestFormatProgress(t *testing.T) {
+	got := formatProgress("a.bin", 1<<20, 4<<20, 2*time.Second, true)
+	want := "a.bin  [======                  ]  1.0 MiB / 4.0 MiB (25%)  512.0 KiB/s  ETA 6s"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	got = formatProgress("stdin", 2048, -1, time.Second, false)
+	if want := "stdin  2.0 KiB  2.0 KiB/s"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestProgressReader(t *testing.T) {
+	var out bytes.Buffer
+	p := &progress{w: &out, start: time.Now(), total: 20}
+	a := p.track("a", 10, strings.NewReader("0123456789"))
+	b := p.track("b", 10, io.MultiReader(strings.NewReader("0123456789")))
+
+	io.ReadAll(a)
+	io.CopyN(io.Discard, b, 4)
+	if a.f.sent != 10 || b.f.sent != 4 {
+		t.Errorf("expected 10 and 4 bytes sent, got %d and %d", a.f.sent, b.f.sent)
+	}
+	// Rewinding for a retry resets the count; readers that cannot seek fail.
+	if _, err := a.Seek(0, io.SeekStart); err != nil || a.f.sent != 0 {
+		t.Errorf("expected the count to follow the seek, got %d (%v)", a.f.sent, err)
+	}
+	if _, err := b.Seek(0, io.SeekStart); err == nil {
+		t.Error("expected error seeking a stream")
+	}
+
+	p.finish(a)
+	p.report(time.Now())
+	got := out.String()
+	if strings.Contains(got, "a  ") || !strings.Contains(got, "b  4 B / 10 B (40%)") || !strings.Contains(got, "total (1 done)  4 B / 20 B") {
+		t.Errorf("unexpected report:\n%s", got)
+	}
+}
+
+func TestNewProgressOff(t *testing.T) {
+	defer func(quiet bool, output string) { rootOpts.quiet, rootOpts.output = quiet, output }(rootOpts.quiet, rootOpts.output)
+
+	rootOpts.quiet, rootOpts.output = true, outputText
+	if p := newProgress(&cobra.Command{}, 1); p != nil {
+		t.Error("expected no progress with --quiet")
+	}
+	rootOpts.quiet, rootOpts.output = false, outputJSON
+	if p := newProgress(&cobra.Command{}, 1); p != nil {
+		t.Error("expected no progress with --json")
+	}
+	// A nil progress tracks nothing but still passes the data through.
+	var p *progress
+	r := p.track("a", 3, strings.NewReader("abc"))
+	if b, _ := io.ReadAll(r); string(b) != "abc" {
+		t.Errorf("expected data to pass through, got %q", b)
+	}
+	p.finish(r)
+	p.close()
+}
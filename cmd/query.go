@@ -0,0 +1,348 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: query.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:34:10
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:34:10
+//
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// query is a compiled --query expression: a small subset of jq paths, which
+// also accepts JSONPath spelling. Supported forms:
+//
+//	.            the whole value ($ in JSONPath)
+//	.name        object field, also ["name"] and .["name"]
+//	.[2]         array element, negative indexes count from the end
+//	.[] .[*]     every element of an array or value of an object
+//	a | b        pipe the results of a into b
+//	length keys  builtins usable as a pipe stage
+type query struct {
+	stages [][]querySegment
+	// multi is set when the expression can yield several values, which are
+	// then collected into a list
+	multi bool
+}
+
+// querySegment is one step of a path.
+type querySegment struct {
+	kind    int
+	key     string
+	index   int
+	builtin string
+}
+
+const (
+	segField = iota
+	segIndex
+	segIterate
+	segBuiltin
+)
+
+// parseQuery compiles a --query expression.
+func parseQuery(expr string) (*query, error) {
+	q := &query{}
+	for _, stage := range splitQueryStages(expr) {
+		segs, err := parseQueryStage(strings.TrimSpace(stage))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --query %q: %w", expr, err)
+		}
+		for _, s := range segs {
+			if s.kind == segIterate {
+				q.multi = true
+			}
+		}
+		q.stages = append(q.stages, segs)
+	}
+	return q, nil
+}
+
+// splitQueryStages splits expr at the pipes between stages, leaving those
+// inside quoted keys and brackets alone.
+func splitQueryStages(expr string) []string {
+	var stages []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '|' && depth == 0:
+			stages = append(stages, expr[start:i])
+			start = i + 1
+		}
+	}
+	return append(stages, expr[start:])
+}
+
+// closingBracket returns the index of the ']' that ends the bracket at the
+// start of s, skipping quoted keys, or -1 if there is none.
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseQueryStage parses a single path or builtin between pipes.
+func parseQueryStage(s string) ([]querySegment, error) {
+	switch s {
+	case "":
+		return nil, fmt.Errorf("empty expression")
+	case "length", "keys":
+		return []querySegment{{kind: segBuiltin, builtin: s}}, nil
+	}
+
+	if s[0] == '$' {
+		s = s[1:]
+	} else if s[0] != '.' && s[0] != '[' {
+		return nil, fmt.Errorf("expression must start with '.', '$' or '['")
+	}
+
+	var segs []querySegment
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '.':
+			i++
+			if i < len(s) && s[i] == '*' {
+				segs = append(segs, querySegment{kind: segIterate})
+				i++
+				continue
+			}
+			j := i
+			for j < len(s) && isQueryIdent(s[j]) {
+				j++
+			}
+			if j > i {
+				segs = append(segs, querySegment{kind: segField, key: s[i:j]})
+			}
+			i = j
+		case '[':
+			end := closingBracket(s[i:])
+			if end < 0 {
+				return nil, fmt.Errorf("missing ']'")
+			}
+			inner := strings.TrimSpace(s[i+1 : i+end])
+			i += end + 1
+			switch {
+			case inner == "" || inner == "*":
+				segs = append(segs, querySegment{kind: segIterate})
+			case inner[0] == '"' || inner[0] == '\'':
+				key, err := strconv.Unquote(`"` + strings.Trim(inner, `"'`) + `"`)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %s", inner)
+				}
+				segs = append(segs, querySegment{kind: segField, key: key})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %q", inner)
+				}
+				segs = append(segs, querySegment{kind: segIndex, index: n})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q", s[i:])
+		}
+	}
+	return segs, nil
+}
+
+func isQueryIdent(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// eval runs the query on a value produced by toGeneric. The result is a
+// single value, or a list when the query iterates.
+func (q *query) eval(v any) (any, error) {
+	values := []any{v}
+	for _, stage := range q.stages {
+		for _, seg := range stage {
+			var next []any
+			for _, cur := range values {
+				out, err := seg.apply(cur)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, out...)
+			}
+			values = next
+		}
+	}
+	if q.multi {
+		if values == nil {
+			values = []any{}
+		}
+		return values, nil
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
+
+// apply evaluates one segment on one value.
+func (s querySegment) apply(v any) ([]any, error) {
+	switch s.kind {
+	case segField:
+		switch obj := v.(type) {
+		case map[string]any:
+			return []any{obj[s.key]}, nil
+		case nil:
+			return []any{nil}, nil
+		default:
+			return nil, fmt.Errorf("cannot get field %q of %s", s.key, queryTypeName(v))
+		}
+	case segIndex:
+		switch list := v.(type) {
+		case []any:
+			i := s.index
+			if i < 0 {
+				i += len(list)
+			}
+			if i < 0 || i >= len(list) {
+				return []any{nil}, nil
+			}
+			return []any{list[i]}, nil
+		case nil:
+			return []any{nil}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with %d", queryTypeName(v), s.index)
+		}
+	case segIterate:
+		switch c := v.(type) {
+		case []any:
+			return c, nil
+		case map[string]any:
+			keys := sortedKeys(c)
+			out := make([]any, len(keys))
+			for i, k := range keys {
+				out[i] = c[k]
+			}
+			return out, nil
+		default:
+			return nil, fmt.Errorf("cannot iterate over %s", queryTypeName(v))
+		}
+	default:
+		switch c := v.(type) {
+		case []any:
+			if s.builtin == "length" {
+				return []any{json.Number(strconv.Itoa(len(c)))}, nil
+			}
+			keys := make([]any, len(c))
+			for i := range c {
+				keys[i] = json.Number(strconv.Itoa(i))
+			}
+			return []any{keys}, nil
+		case map[string]any:
+			if s.builtin == "length" {
+				return []any{json.Number(strconv.Itoa(len(c)))}, nil
+			}
+			var keys []any
+			for _, k := range sortedKeys(c) {
+				keys = append(keys, k)
+			}
+			return []any{keys}, nil
+		case string:
+			if s.builtin == "length" {
+				return []any{json.Number(strconv.Itoa(len([]rune(c))))}, nil
+			}
+		case nil:
+			if s.builtin == "length" {
+				return []any{json.Number("0")}, nil
+			}
+		}
+		return nil, fmt.Errorf("%s has no %s", queryTypeName(v), s.builtin)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func queryTypeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	default:
+		return "number"
+	}
+}
+
+// printQueryResult writes a query result like jq -r: strings unquoted,
+// other scalars as JSON, objects and arrays as indented JSON. Lists produced
+// by iterating queries are written one element per line.
+func printQueryResult(w io.Writer, v any, multi bool) error {
+	values := []any{v}
+	if list, ok := v.([]any); ok && multi {
+		values = list
+	}
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			fmt.Fprintln(w, v)
+		case map[string]any, []any:
+			if err := printJSON(w, v); err != nil {
+				return err
+			}
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(w, string(b))
+		}
+	}
+	return nil
+}
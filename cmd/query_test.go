@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: query_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:34:10
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:34:10
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const queryTestData = `{
+  "short_url": "https://s.ee/abc",
+  "tags": [{"id": 1, "name": "promo"}, {"id": 2, "name": "blog"}],
+  "meta": {"a b": "spaced", "a|b": "piped", "x]": "bracket"}
+}`
+
+func TestQuery_Eval(t *testing.T) {
+	var data any
+	dec := json.NewDecoder(bytes.NewReader([]byte(queryTestData)))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{".short_url", `"https://s.ee/abc"`},
+		{"$.short_url", `"https://s.ee/abc"`},
+		{".", ""},
+		{".tags[0].name", `"promo"`},
+		{".tags[-1].id", `2`},
+		{".tags[].name", `["promo","blog"]`},
+		{"$.tags[*].id", `[1,2]`},
+		{".tags | length", `2`},
+		{".tags[1] | .name", `"blog"`},
+		{`.meta["a b"]`, `"spaced"`},
+		{`.meta["a|b"]`, `"piped"`},
+		{`.meta["a|b"] | length`, `5`},
+		{`.meta["x]"]`, `"bracket"`},
+		{".meta | keys", `["a b","a|b","x]"]`},
+		{".missing", `null`},
+		{".tags[5]", `null`},
+	}
+	for _, tt := range tests {
+		q, err := parseQuery(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: parse failed: %v", tt.expr, err)
+		}
+		got, err := q.eval(data)
+		if err != nil {
+			t.Fatalf("%s: eval failed: %v", tt.expr, err)
+		}
+		b, _ := json.Marshal(got)
+		if tt.want != "" && string(b) != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.expr, tt.want, b)
+		}
+	}
+}
+
+func TestQuery_Errors(t *testing.T) {
+	for _, expr := range []string{"", "short_url", ".tags[x]", ".tags[0", ". | "} {
+		if _, err := parseQuery(expr); err == nil {
+			t.Errorf("%q: expected parse error, got nil", expr)
+		}
+	}
+
+	q, _ := parseQuery(".short_url.x")
+	if _, err := q.eval(map[string]any{"short_url": "s"}); err == nil {
+		t.Error("expected error indexing a string, got nil")
+	}
+}
+
+func TestPrintQueryResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printQueryResult(&buf, []any{"a", json.Number("1"), nil}, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "a\n1\nnull\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+}
@@ -0,0 +1,337 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: retry.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:00:11
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:00:11
+//
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// defaultRetries is how many times a failed request is retried unless
+	// the retries setting says otherwise.
+	defaultRetries = 3
+
+	// defaultRetryMaxWait caps a single wait between two attempts.
+	defaultRetryMaxWait = 30 * time.Second
+
+	// retryBaseWait is the wait before the first retry; it doubles with
+	// every further attempt.
+	retryBaseWait = 500 * time.Millisecond
+)
+
+// retryPolicy controls how failed API requests are retried.
+type retryPolicy struct {
+	// retries is the number of attempts after the first one
+	retries int
+	// maxWait caps a single wait. A Retry-After beyond it is not waited for.
+	maxWait time.Duration
+}
+
+// wait returns how long to wait before retry number attempt (starting at 0).
+// The server's Retry-After wins; otherwise the wait grows exponentially
+// with jitter. ok is false when the server asks for more than maxWait.
+func (p retryPolicy) wait(attempt int, retryAfter time.Duration) (d time.Duration, ok bool) {
+	if retryAfter > 0 {
+		return retryAfter, retryAfter <= p.maxWait
+	}
+	d = p.maxWait
+	if attempt < 30 && retryBaseWait<<attempt < p.maxWait {
+		d = retryBaseWait << attempt
+	}
+	// Full jitter over the upper half keeps parallel clients apart without
+	// retrying almost immediately.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1)), true
+}
+
+// retryLaterError reports a response that asked to be retried for a request
+// whose body cannot be replayed by the transport. Callers that can rewind
+// the body themselves retry with retryStream.
+type retryLaterError struct {
+	status     int
+	retryAfter time.Duration
+	body       string
+}
+
+func (e *retryLaterError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.status, e.body)
+}
+
+// retryTransport retries requests that failed for transient reasons: rate
+// limiting (429), gateway errors (502, 503, 504) and dropped connections.
+// Requests that may change data twice are only retried when the server
+// cannot have processed them. It also applies the request timeout to each
+// attempt instead of to all of them together.
+type retryTransport struct {
+	base    http.RoundTripper
+	policy  retryPolicy
+	timeout time.Duration
+	// sleep waits between attempts; tests replace it
+	sleep func(ctx context.Context, d time.Duration) error
+	// log receives a line for every retry
+	log io.Writer
+}
+
+// newRetryTransport returns a retryTransport over the default transport.
+func newRetryTransport(policy retryPolicy, timeout time.Duration) *retryTransport {
+	return &retryTransport{
+		base:    http.DefaultTransport,
+		policy:  policy,
+		timeout: timeout,
+		sleep:   sleepContext,
+		log:     os.Stderr,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := isIdempotent(req.Method)
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+
+		resp, err := t.attempt(r)
+		var retryAfter time.Duration
+		switch {
+		case err != nil:
+			if !retryableError(req.Context(), err, idempotent) {
+				return nil, err
+			}
+		case retryableStatus(resp, idempotent):
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if !replayable {
+				if t.policy.retries == 0 {
+					return resp, nil
+				}
+				b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+				resp.Body.Close()
+				return nil, &retryLaterError{status: resp.StatusCode, retryAfter: retryAfter, body: string(b)}
+			}
+		default:
+			return resp, nil
+		}
+
+		if attempt >= t.policy.retries || !replayable {
+			return resp, err
+		}
+		d, ok := t.policy.wait(attempt, retryAfter)
+		if !ok {
+			return resp, err
+		}
+		reason := fmt.Sprint(err)
+		if resp != nil {
+			reason = "status " + strconv.Itoa(resp.StatusCode)
+			// Drain the body so that the connection can be reused.
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		fmt.Fprintf(t.log, "%s %s failed (%s), retrying in %s (%d/%d)\n",
+			req.Method, req.URL.Path, reason, d.Round(100*time.Millisecond), attempt+1, t.policy.retries)
+		if err := t.sleep(req.Context(), d); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt sends a single request with its own timeout. The timeout keeps
+// running while the caller reads the response body.
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody releases the timeout of an attempt once its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// isIdempotent reports whether repeating a request of method has the same
+// effect as sending it once. The API's PUT and DELETE calls qualify.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryableStatus reports whether resp asks for another attempt. Requests
+// that create content are only retried when rate limited, because the
+// server rejects those before doing anything.
+func retryableStatus(resp *http.Response, idempotent bool) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// retryableError reports whether a failed attempt is worth repeating.
+// Requests that create content are only retried when the connection could
+// not be established, so they cannot have reached the server.
+func retryableError(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an
+// HTTP date. It returns 0 when the header is missing or invalid.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		return time.Duration(n) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryStream calls send until it succeeds, retrying the responses that
+// the transport could not retry because the request body is a stream. Each
+// attempt sends its own view of r, and r is rewound before every retry;
+// streams that cannot seek, such as stdin, are not retried.
+func retryStream(r io.Reader, send func(body io.Reader) error) error {
+	t, _ := apiTransport()
+	for attempt := 0; ; attempt++ {
+		body := &attemptReader{r: r}
+		err := send(body)
+		// The SDK copies the body in a goroutine that can outlive an early
+		// response, so it must be cut off before r is rewound.
+		body.close()
+		var later *retryLaterError
+		if err == nil || t == nil || attempt >= t.policy.retries || !errors.As(err, &later) {
+			return err
+		}
+		seeker, ok := r.(io.Seeker)
+		if !ok {
+			return err
+		}
+		d, ok := t.policy.wait(attempt, later.retryAfter)
+		if !ok {
+			return err
+		}
+		if _, serr := seeker.Seek(0, io.SeekStart); serr != nil {
+			return err
+		}
+		fmt.Fprintf(t.log, "upload failed (status %d), retrying in %s (%d/%d)\n",
+			later.status, d.Round(100*time.Millisecond), attempt+1, t.policy.retries)
+		if serr := t.sleep(context.Background(), d); serr != nil {
+			return err
+		}
+	}
+}
+
+// attemptReader is the body of one attempt of retryStream. Once closed, it
+// no longer reads from r, so that a request still sending it cannot race
+// with the rewind for the next attempt.
+type attemptReader struct {
+	mu     sync.Mutex
+	r      io.Reader
+	closed bool
+}
+
+func (a *attemptReader) Read(b []byte) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return 0, errAttemptOver
+	}
+	return a.r.Read(b)
+}
+
+// close waits for a read in progress and stops all further reads.
+func (a *attemptReader) close() {
+	a.mu.Lock()
+	a.closed = true
+	a.mu.Unlock()
+}
+
+// errAttemptOver is returned to a request that reads its body after its
+// attempt has ended.
+var errAttemptOver = errors.New("upload attempt is over")
+
+// apiTransport returns the retry transport of apiClient, if any.
+func apiTransport() (*retryTransport, bool) {
+	if apiClient == nil || apiClient.HTTPClient == nil {
+		return nil, false
+	}
+	t, ok := apiClient.HTTPClient.Transport.(*retryTransport)
+	return t, ok
+}
@@ -0,0 +1,212 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: retry_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:00:11
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:00:11
+//
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	seesdk "github.com/sdotee/sdk.go"
+)
+
+// testRetryClient points apiClient at a server that fails the first
+// failures requests of each kind with status, and records the waits.
+func testRetryClient(t *testing.T, status, failures int, header http.Header) (hits *atomic.Int32, waits *[]time.Duration) {
+	t.Helper()
+	hits = &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		if int(hits.Add(1)) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			io.WriteString(w, `{"code":1,"message":"busy"}`)
+			return
+		}
+		io.WriteString(w, `{"code":200,"message":"ok","data":{"short_url":"https://s.ee/a","slug":"a","url":"https://f.example/x","delete":"k"}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	waits = &[]time.Duration{}
+	tr := newRetryTransport(retryPolicy{retries: 3, maxWait: 10 * time.Second}, time.Second)
+	tr.log = io.Discard
+	tr.sleep = func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	client := seesdk.NewClient(seesdk.Config{BaseURL: srv.URL, APIKey: "k"})
+	client.HTTPClient.Transport = tr
+
+	prev := apiClient
+	apiClient = client
+	t.Cleanup(func() { apiClient = prev })
+	return hits, waits
+}
+
+func TestRetryPolicyWait(t *testing.T) {
+	p := retryPolicy{retries: 5, maxWait: 3 * time.Second}
+	for attempt, limit := range []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		d, ok := p.wait(attempt, 0)
+		if !ok || d < limit/2 || d > limit {
+			t.Errorf("attempt %d: expected wait in [%v, %v], got %v", attempt, limit/2, limit, d)
+		}
+	}
+	if d, ok := p.wait(0, 2*time.Second); !ok || d != 2*time.Second {
+		t.Errorf("expected Retry-After to be used, got %v", d)
+	}
+	if _, ok := p.wait(0, time.Minute); ok {
+		t.Error("expected a Retry-After beyond the maximum wait to give up")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if got := parseRetryAfter("7", now); got != 7*time.Second {
+		t.Errorf("expected 7s, got %v", got)
+	}
+	if got := parseRetryAfter("Tue, 10 Mar 2026 12:00:30 GMT", now); got != 30*time.Second {
+		t.Errorf("expected 30s, got %v", got)
+	}
+	for _, v := range []string{"", "soon", "-1", "Tue, 10 Mar 2026 11:00:00 GMT"} {
+		if got := parseRetryAfter(v, now); got != 0 {
+			t.Errorf("%q: expected 0, got %v", v, got)
+		}
+	}
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	hits, waits := testRetryClient(t, http.StatusServiceUnavailable, 2, nil)
+	if _, err := apiClient.DeleteShortURL(seesdk.DeleteURLRequest{Domain: "s.ee", Slug: "a"}); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if hits.Load() != 3 || len(*waits) != 2 {
+		t.Errorf("expected 3 attempts and 2 waits, got %d and %v", hits.Load(), *waits)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	hits, _ := testRetryClient(t, http.StatusBadGateway, 10, nil)
+	_, err := apiClient.GetTags()
+	if err == nil || !strings.Contains(err.Error(), "status 502") {
+		t.Errorf("expected the last error, got %v", err)
+	}
+	if hits.Load() != 4 {
+		t.Errorf("expected 4 attempts, got %d", hits.Load())
+	}
+}
+
+func TestRetryCreate(t *testing.T) {
+	// A 502 may come after the link was created, so it is not retried.
+	hits, _ := testRetryClient(t, http.StatusBadGateway, 1, nil)
+	if _, err := apiClient.CreateShortURL(seesdk.CreateShortURLRequest{Domain: "s.ee", TargetURL: "https://example.com"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", hits.Load())
+	}
+
+	// Rate limited requests were not processed and are retried.
+	hits, waits := testRetryClient(t, http.StatusTooManyRequests, 1, http.Header{"Retry-After": {"2"}})
+	if _, err := apiClient.CreateShortURL(seesdk.CreateShortURLRequest{Domain: "s.ee", TargetURL: "https://example.com"}); err != nil {
+		t.Fatalf("expected success after retry, got %v", err)
+	}
+	if hits.Load() != 2 || len(*waits) != 1 || (*waits)[0] != 2*time.Second {
+		t.Errorf("expected one retry after 2s, got %d attempts and %v", hits.Load(), *waits)
+	}
+}
+
+func TestRetryStream(t *testing.T) {
+	hits, _ := testRetryClient(t, http.StatusTooManyRequests, 2, nil)
+	r := strings.NewReader("content")
+	err := retryStream(r, func(body io.Reader) error {
+		_, err := apiClient.UploadFile(seesdk.UploadFileRequest{Filename: "a.txt", File: body})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", hits.Load())
+	}
+
+	// Without a way to rewind, the first answer is final.
+	hits, _ = testRetryClient(t, http.StatusTooManyRequests, 1, nil)
+	var once io.Reader = strings.NewReader("content")
+	once = io.MultiReader(once)
+	err = retryStream(once, func(body io.Reader) error {
+		_, err := apiClient.UploadFile(seesdk.UploadFileRequest{Filename: "a.txt", File: body})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "status 429") {
+		t.Errorf("expected rate limit error, got %v", err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", hits.Load())
+	}
+}
+
+func TestRetryStreamEarlyResponse(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 1<<20)
+	var hits atomic.Int32
+	var got []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt is refused before its body is read, and the
+		// body is only drained a while later, so the client is still
+		// sending it when it gets the answer and retries.
+		if hits.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			io.WriteString(w, `{"code":1,"message":"busy"}`)
+			w.(http.Flusher).Flush()
+			time.Sleep(100 * time.Millisecond)
+			io.Copy(io.Discard, r.Body)
+			return
+		}
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		got, _ = io.ReadAll(f)
+		io.WriteString(w, `{"code":200,"data":{"url":"https://f.example/x","delete":"k"}}`)
+	}))
+	defer srv.Close()
+	tr := newRetryTransport(retryPolicy{retries: 3, maxWait: 10 * time.Second}, 10*time.Second)
+	tr.log = io.Discard
+	tr.sleep = func(context.Context, time.Duration) error { return nil }
+	prev := apiClient
+	apiClient = seesdk.NewClient(seesdk.Config{BaseURL: srv.URL, APIKey: "k"})
+	apiClient.HTTPClient.Transport = tr
+	defer func() { apiClient = prev }()
+
+	r := bytes.NewReader(content)
+	err := retryStream(r, func(body io.Reader) error {
+		_, err := apiClient.UploadFile(seesdk.UploadFileRequest{Filename: "big.bin", File: body})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("expected success after the retry, got %v", err)
+	}
+	if hits.Load() != 2 || !bytes.Equal(got, content) {
+		t.Errorf("expected the whole body on the second attempt, got %d attempts and %d of %d bytes", hits.Load(), len(got), len(content))
+	}
+}
@@ -9,7 +9,7 @@
 // File Created: 2025-12-22 22:23:57
 //
 // Modified By: S.EE Development Team <dev@s.ee>
-// Last Modified: 2026-01-19 19:26:47
+// Last Modified: 2026-10-18 09:07:22
 //
 
 package cmd
@@ -17,14 +17,17 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
 	"time"
 
 	seesdk "github.com/sdotee/sdk.go"
 	"github.com/spf13/cobra"
 )
 
+// skipClientAnnotation marks commands that run without an API client
+const skipClientAnnotation = "see_skip_client"
+
 var (
 	// apiClient is the global SDK client instance used by commands
 	apiClient *seesdk.Client
@@ -33,8 +36,18 @@ var (
 	rootOpts struct {
 		baseURL    string
 		apiKey     string
+		apiKeyCmd  string
 		timeout    time.Duration
+		retries    int
+		retryWait  time.Duration
 		jsonOutput bool
+		output     string
+		columns    []string
+		query      string
+		profile    string
+		configPath string
+		refresh    bool
+		quiet      bool
 	}
 
 	// BuildVersion is the version of the binary, injected at build time
@@ -49,21 +62,51 @@ var rootCmd = &cobra.Command{
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		if cmd.Name() == "version" {
+		if err := checkOutputFlags(); err != nil {
+			return err
+		}
+		if skipsClient(cmd) {
 			return nil
 		}
-		if rootOpts.apiKey == "" {
-			return errors.New("missing API key: use --api-key or set SEE_API_KEY")
+		if err := resolveSettings(cmd); err != nil {
+			return err
 		}
-		apiClient = seesdk.NewClient(seesdk.Config{
-			BaseURL: rootOpts.baseURL,
-			APIKey:  rootOpts.apiKey,
-			Timeout: rootOpts.timeout,
-		})
-		return nil
+		return ensureClient()
 	},
 }
 
+// ensureClient creates apiClient from the resolved settings unless it
+// exists already. Commands that skip the client call it once they find out
+// that they need the API after all.
+func ensureClient() error {
+	if apiClient != nil {
+		return nil
+	}
+	if err := resolveAPIKey(); err != nil {
+		return err
+	}
+	if rootOpts.apiKey == "" {
+		return errors.New("missing API key: run 'see login', use --api-key or --api-key-cmd, set SEE_API_KEY or add api_key to a config profile")
+	}
+	apiClient = seesdk.NewClient(seesdk.Config{
+		BaseURL: rootOpts.baseURL,
+		APIKey:  rootOpts.apiKey,
+		Timeout: rootOpts.timeout,
+	})
+	// The transport applies the timeout to each attempt, so that retries
+	// are not cut short by it.
+	apiClient.HTTPClient.Timeout = 0
+	transport := newRetryTransport(retryPolicy{
+		retries: rootOpts.retries,
+		maxWait: rootOpts.retryWait,
+	}, rootOpts.timeout)
+	if rootOpts.quiet {
+		transport.log = io.Discard
+	}
+	apiClient.HTTPClient.Transport = transport
+	return nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute(v, t string) {
@@ -81,35 +124,62 @@ func Execute(v, t string) {
 }
 
 func init() {
-	defaultBaseURL := os.Getenv("SEE_BASE_URL")
-	if defaultBaseURL == "" {
-		defaultBaseURL = seesdk.DefaultBaseURL
-	}
-	rootCmd.PersistentFlags().StringVar(&rootOpts.baseURL, "base-url", defaultBaseURL, "API base URL")
-
-	defaultAPIKey := os.Getenv("SEE_API_KEY")
-	rootCmd.PersistentFlags().StringVar(&rootOpts.apiKey, "api-key", defaultAPIKey, "API key (or set SEE_API_KEY env)")
-
-	defaultTimeout := seesdk.DefaultTimeout
-	if envTimeout := os.Getenv("SEE_TIMEOUT"); envTimeout != "" {
-		if d, err := strconv.ParseUint(envTimeout, 10, 64); err == nil {
-			defaultTimeout = time.Duration(d) * time.Second
-		}
-	}
-	rootCmd.PersistentFlags().BoolVar(&rootOpts.jsonOutput, "json", false, "Output in JSON format")
-	rootCmd.PersistentFlags().DurationVar(&rootOpts.timeout, "timeout", defaultTimeout, "HTTP timeout")
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&rootOpts.baseURL, "base-url", seesdk.DefaultBaseURL, "API base URL (or set SEE_BASE_URL env)")
+	flags.StringVar(&rootOpts.apiKey, "api-key", "", "API key (or set SEE_API_KEY env)")
+	flags.StringVar(&rootOpts.apiKeyCmd, "api-key-cmd", "", "Command that prints the API key (or set SEE_API_KEY_CMD env)")
+	flags.BoolVar(&rootOpts.jsonOutput, "json", false, "Output in JSON format (same as --output json)")
+	flags.StringVarP(&rootOpts.output, "output", "o", "", "Output format: table, json, yaml, csv, ndjson or template=<go template>")
+	flags.StringSliceVar(&rootOpts.columns, "columns", nil, "Columns to output, e.g. slug,target,expire_at")
+	flags.StringVar(&rootOpts.query, "query", "", "Extract fields from the response with a jq-style path, e.g. .short_url or .[].url")
+	flags.DurationVar(&rootOpts.timeout, "timeout", seesdk.DefaultTimeout, "HTTP timeout (or set SEE_TIMEOUT env)")
+	flags.StringVar(&rootOpts.profile, "profile", "", "Config profile to use (or set SEE_PROFILE env)")
+	flags.StringVar(&rootOpts.configPath, "config", "", "Config file path (or set SEE_CONFIG env)")
+	flags.BoolVar(&rootOpts.refresh, "refresh", false, "Refresh cached domains and tags from the API")
+	flags.BoolVarP(&rootOpts.quiet, "quiet", "q", false, "Do not report progress and retries on stderr")
+	bindSetting(flags, "base-url", "base_url")
+	bindSetting(flags, "api-key", "api_key")
+	bindSetting(flags, "api-key-cmd", "api_key_cmd")
+	flags.IntVar(&rootOpts.retries, "retries", defaultRetries, "Retries of requests that failed transiently (or set SEE_RETRIES env)")
+	flags.DurationVar(&rootOpts.retryWait, "retry-max-wait", defaultRetryMaxWait, "Longest wait before a retry (or set SEE_RETRY_MAX_WAIT env)")
+	bindSetting(flags, "timeout", "timeout")
+	bindSetting(flags, "retries", "retries")
+	bindSetting(flags, "retry-max-wait", "retry_max_wait")
 
 	rootCmd.AddCommand(domainsCmd)
 	rootCmd.AddCommand(tagsCmd)
 	rootCmd.AddCommand(shorturlCmd)
 	rootCmd.AddCommand(textCmd)
 	rootCmd.AddCommand(fileCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+// skipsClient reports whether cmd, or one of its parents, does not need an
+// API client and therefore must not fail on missing credentials.
+func skipsClient(cmd *cobra.Command) bool {
+	// Completion functions set up the client themselves when they need it.
+	if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+		return true
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[skipClientAnnotation] != "" {
+			return true
+		}
+	}
+	return false
 }
 
 var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Print the version number of see-cli",
+	Use:         "version",
+	Short:       "Print the version number of see-cli",
+	Annotations: map[string]string{skipClientAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("see-cli version %s (%s)\n", BuildVersion, BuildTime)
 	},
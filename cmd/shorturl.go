@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2025 S.EE Development Team
+// Copyright (c) 2025-2026 S.EE Development Team
 //
 // This source code is licensed under the MIT License,
 // which is located in the LICENSE file in the source tree's root directory.
@@ -9,14 +9,16 @@
 // File Created: 2025-12-22 22:25:46
 //
 // Modified By: S.EE Development Team <dev@s.ee>
-// Last Modified: 2025-12-23 11:08:08
+// Last Modified: 2026-10-18 08:58:15
 //
 
 package cmd
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	seesdk "github.com/sdotee/sdk.go"
 	"github.com/spf13/cobra"
@@ -29,8 +31,9 @@ var (
 		slug                  string
 		title                 string
 		password              string
-		expireAt              int64
+		expiry                expiryOpts
 		tagIDs                []int64
+		tags                  []string
 		expirationRedirectURL string
 	}
 
@@ -45,6 +48,9 @@ var (
 	shortDeleteOpts struct {
 		domain string
 	}
+
+	// shortListOpts holds options for listing short URLs from the ledger
+	shortListOpts ledgerFilter
 )
 
 var shorturlCmd = &cobra.Command{
@@ -56,20 +62,34 @@ func init() {
 	shorturlCmd.AddCommand(shorturlCreateCmd)
 	shorturlCmd.AddCommand(shorturlUpdateCmd)
 	shorturlCmd.AddCommand(shorturlDeleteCmd)
+	shorturlCmd.AddCommand(shorturlListCmd)
 
 	shorturlCreateCmd.Flags().StringVar(&shortCreateOpts.domain, "domain", "s.ee", "Short domain")
+	bindSetting(shorturlCreateCmd.Flags(), "domain", "domain")
+	shorturlCreateCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindShortURL))
 	shorturlCreateCmd.Flags().StringVar(&shortCreateOpts.slug, "slug", "", "Custom slug")
 	shorturlCreateCmd.Flags().StringVar(&shortCreateOpts.title, "title", "", "Title")
 	shorturlCreateCmd.Flags().StringVar(&shortCreateOpts.password, "password", "", "Password")
-	shorturlCreateCmd.Flags().Int64Var(&shortCreateOpts.expireAt, "expire-at", 0, "Expire at (unix seconds)")
 	shorturlCreateCmd.Flags().Int64SliceVar(&shortCreateOpts.tagIDs, "tag-ids", nil, "Tag IDs")
+	shorturlCreateCmd.RegisterFlagCompletionFunc("tag-ids", completeTagIDs)
+	shorturlCreateCmd.Flags().StringSliceVar(&shortCreateOpts.tags, "tag", nil, "Tag names (or IDs), comma-separated")
+	shorturlCreateCmd.RegisterFlagCompletionFunc("tag", completeTags)
 	shorturlCreateCmd.Flags().StringVar(&shortCreateOpts.expirationRedirectURL, "expiration-redirect-url", "", "Redirect URL after expiration")
+	addExpiryFlags(shorturlCreateCmd, &shortCreateOpts.expiry)
 
+	shorturlUpdateCmd.ValidArgsFunction = completeSlugs(kindShortURL)
 	shorturlUpdateCmd.Flags().StringVar(&shortUpdateOpts.domain, "domain", "s.ee", "Short domain")
+	bindSetting(shorturlUpdateCmd.Flags(), "domain", "domain")
+	shorturlUpdateCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindShortURL))
 	shorturlUpdateCmd.Flags().StringVar(&shortUpdateOpts.targetURL, "target-url", "", "New target URL")
 	shorturlUpdateCmd.Flags().StringVar(&shortUpdateOpts.title, "title", "", "Title")
 
+	shorturlDeleteCmd.ValidArgsFunction = completeSlugs(kindShortURL)
 	shorturlDeleteCmd.Flags().StringVar(&shortDeleteOpts.domain, "domain", "s.ee", "Short domain")
+	bindSetting(shorturlDeleteCmd.Flags(), "domain", "domain")
+	shorturlDeleteCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindShortURL))
+
+	addLedgerFilterFlags(shorturlListCmd, &shortListOpts)
 }
 
 var shorturlCreateCmd = &cobra.Command{
@@ -77,14 +97,25 @@ var shorturlCreateCmd = &cobra.Command{
 	Short: "Create a short URL",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		expireAt, err := shortCreateOpts.expiry.expireAt(time.Now())
+		if err != nil {
+			return err
+		}
+		tagIDs, err := resolveTags(shortCreateOpts.tags)
+		if err != nil {
+			return err
+		}
+		if err := checkDomain(kindShortURL, shortCreateOpts.domain); err != nil {
+			return err
+		}
 		req := seesdk.CreateShortURLRequest{
 			TargetURL:             args[0],
 			Domain:                shortCreateOpts.domain,
 			CustomSlug:            shortCreateOpts.slug,
 			Title:                 shortCreateOpts.title,
 			Password:              shortCreateOpts.password,
-			ExpireAt:              shortCreateOpts.expireAt,
-			TagIDs:                shortCreateOpts.tagIDs,
+			ExpireAt:              expireAt,
+			TagIDs:                append(shortCreateOpts.tagIDs, tagIDs...),
 			ExpirationRedirectURL: shortCreateOpts.expirationRedirectURL,
 		}
 
@@ -92,11 +123,24 @@ var shorturlCreateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp.Data)
-		}
-		fmt.Fprintln(cmd.OutOrStdout(), resp.Data.ShortURL)
-		return nil
+		ledgerCreated(cmd, ledgerRecord{
+			Kind:     kindShortURL,
+			Domain:   req.Domain,
+			Slug:     resp.Data.Slug,
+			URL:      resp.Data.ShortURL,
+			Target:   req.TargetURL,
+			Title:    req.Title,
+			TagIDs:   req.TagIDs,
+			ExpireAt: req.ExpireAt,
+		})
+		return render(cmd, result{
+			data:    resp.Data,
+			columns: []string{"short_url", "slug"},
+			text: func(w io.Writer) error {
+				_, err := fmt.Fprintln(w, resp.Data.ShortURL)
+				return err
+			},
+		})
 	},
 }
 
@@ -117,13 +161,13 @@ var shorturlUpdateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp)
-		}
-		if resp.Message != "" {
-			fmt.Fprintln(cmd.OutOrStdout(), resp.Message)
-		}
-		return nil
+		ledgerUpdated(cmd, kindShortURL, shortUpdateOpts.domain, args[0], func(r *ledgerRecord) {
+			r.Target = shortUpdateOpts.targetURL
+			if shortUpdateOpts.title != "" {
+				r.Title = shortUpdateOpts.title
+			}
+		})
+		return render(cmd, messageResult(resp, resp.Message))
 	},
 }
 
@@ -139,12 +183,17 @@ var shorturlDeleteCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp)
-		}
-		if resp.Message != "" {
-			fmt.Fprintln(cmd.OutOrStdout(), resp.Message)
-		}
-		return nil
+		ledgerDeleted(cmd, kindShortURL, shortDeleteOpts.domain, args[0])
+		return render(cmd, messageResult(resp, resp.Message))
+	},
+}
+
+var shorturlListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List short URLs created with this CLI (from the local ledger)",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listLedger(cmd, kindShortURL, &shortListOpts)
 	},
 }
@@ -0,0 +1,170 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: shorturl_get.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:21:10
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:21:10
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+// shortGetOpts holds options for showing a short URL
+var shortGetOpts struct {
+	domain string
+}
+
+// shortURLInfo is what shorturl get renders.
+type shortURLInfo struct {
+	ShortURL string   `json:"short_url"`
+	Domain   string   `json:"domain"`
+	Slug     string   `json:"slug"`
+	Target   string   `json:"target,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	ExpireAt int64    `json:"expire_at,omitempty"`
+	TagIDs   []int64  `json:"tag_ids,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	// Status is the status of the short URL when requested
+	Status int `json:"status"`
+	// InLedger reports whether the link was created with this CLI, which
+	// is where the title, expiry and tags come from
+	InLedger bool `json:"in_ledger"`
+}
+
+var shorturlGetCmd = &cobra.Command{
+	Use:   "get <slug|url>",
+	Short: "Show the target, title, expiry and tags of a short URL",
+	Long: `Show the target, title, expiry and tags of a short URL.
+
+The API has no endpoint to look up a link, so the target is found by
+requesting the short URL without following its redirect. The title, expiry
+and tags come from the local ledger and are only known for links created with
+this CLI.`,
+	Args:              cobra.ExactArgs(1),
+	Annotations:       map[string]string{skipClientAnnotation: "true"},
+	ValidArgsFunction: completeSlugs(kindShortURL),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveSettings(cmd); err != nil {
+			return err
+		}
+		link := contentURL(shortGetOpts.domain, args[0])
+		u, err := url.Parse(link)
+		if err != nil {
+			return fmt.Errorf("invalid URL %q: %w", link, err)
+		}
+		info := shortURLInfo{ShortURL: link, Domain: u.Host, Slug: strings.TrimPrefix(u.Path, "/")}
+
+		l, err := loadLedger()
+		if err != nil {
+			return err
+		}
+		if r := l.find(kindShortURL, info.Domain, info.Slug); r != nil {
+			info.InLedger = true
+			info.Target = r.Target
+			info.Title = r.Title
+			info.ExpireAt = r.ExpireAt
+			info.TagIDs = r.TagIDs
+		}
+
+		status, target, err := resolveRedirect(link)
+		if err != nil {
+			return err
+		}
+		info.Status = status
+		if status == http.StatusNotFound {
+			return fmt.Errorf("%s not found: it may have expired or been deleted", link)
+		}
+		// The redirect is the current target, even if the link was updated
+		// elsewhere since it was recorded.
+		if target != "" {
+			info.Target = target
+		}
+		info.Tags = tagNames(info.TagIDs)
+
+		return render(cmd, result{
+			data:    info,
+			columns: []string{"short_url", "target", "title", "expire_at", "tags"},
+			text: func(w io.Writer) error {
+				return writeShortURLInfo(w, info, target != "")
+			},
+		})
+	},
+}
+
+// writeShortURLInfo prints info for humans. redirected reports whether the
+// target was seen in a redirect.
+func writeShortURLInfo(w io.Writer, info shortURLInfo, redirected bool) error {
+	fmt.Fprintf(w, "Short URL: %s\n", info.ShortURL)
+	switch {
+	case redirected:
+		fmt.Fprintf(w, "Target: %s\n", info.Target)
+	case info.Target != "":
+		fmt.Fprintf(w, "Target: %s (from the ledger; the link did not redirect, status %d)\n", info.Target, info.Status)
+	default:
+		fmt.Fprintf(w, "Target: unknown (the link did not redirect, status %d; it may be password-protected)\n", info.Status)
+	}
+	if !info.InLedger {
+		_, err := fmt.Fprintln(w, "Title, expiry and tags are only known for links created with this CLI.")
+		return err
+	}
+	if info.Title != "" {
+		fmt.Fprintf(w, "Title: %s\n", info.Title)
+	}
+	fmt.Fprintf(w, "Expires: %s\n", formatExpireAt(info.ExpireAt))
+	if len(info.Tags) > 0 {
+		fmt.Fprintf(w, "Tags: %s\n", strings.Join(info.Tags, ", "))
+	}
+	return nil
+}
+
+// tagNames returns the names of the tags with ids. Without an API key only
+// the tag cache is read; IDs without a known name are kept as numbers.
+func tagNames(ids []int64) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	var tags []seesdk.Tag
+	if ensureClient() == nil {
+		tags, _, _ = cachedTags()
+	} else {
+		readCache("tags", cacheTTL(), &tags)
+	}
+	names := map[int64]string{}
+	for _, t := range tags {
+		names[int64(t.ID)] = t.Name
+	}
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		if name, ok := names[id]; ok {
+			out[i] = name
+		} else {
+			out[i] = strconv.FormatInt(id, 10)
+		}
+	}
+	return out
+}
+
+func init() {
+	shorturlCmd.AddCommand(shorturlGetCmd)
+
+	shorturlGetCmd.Flags().StringVar(&shortGetOpts.domain, "domain", "s.ee", "Short domain")
+	bindSetting(shorturlGetCmd.Flags(), "domain", "domain")
+	shorturlGetCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindShortURL))
+}
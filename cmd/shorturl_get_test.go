@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: shorturl_get_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:21:10
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:21:10
+//
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+func TestShortURLGet(t *testing.T) {
+	withTestConfig(t, "")
+	withTestTags(t, seesdk.Tag{ID: 1, Name: "launch"})
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	prev := apiClient
+	apiClient = seesdk.NewClient(seesdk.Config{BaseURL: "http://127.0.0.1:0", APIKey: "k"})
+	defer func() { apiClient = prev }()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/abc":
+			http.Redirect(w, r, "https://example.com/new", http.StatusFound)
+		case "/locked":
+			w.Write([]byte("<form>password</form>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+	ledgerCreated(&cobra.Command{}, ledgerRecord{
+		Kind: kindShortURL, Domain: host, Slug: "abc",
+		Target: "https://example.com/old", Title: "Launch", TagIDs: []int64{1, 9},
+	})
+
+	var out bytes.Buffer
+	shorturlGetCmd.SetOut(&out)
+	defer shorturlGetCmd.SetOut(nil)
+	if err := shorturlGetCmd.RunE(shorturlGetCmd, []string{srv.URL + "/abc"}); err != nil {
+		t.Fatalf("shorturl get failed: %v", err)
+	}
+	got := out.String()
+	// The redirect wins over the target recorded at creation.
+	for _, want := range []string{"Target: https://example.com/new\n", "Title: Launch", "Expires: never", "Tags: launch, 9"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q:\n%s", want, got)
+		}
+	}
+
+	out.Reset()
+	if err := shorturlGetCmd.RunE(shorturlGetCmd, []string{srv.URL + "/locked"}); err != nil {
+		t.Fatalf("shorturl get failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Target: unknown") {
+		t.Errorf("expected an unknown target:\n%s", out.String())
+	}
+
+	if err := shorturlGetCmd.RunE(shorturlGetCmd, []string{srv.URL + "/gone"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestResolveRedirectRelative(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/landing")
+		w.WriteHeader(http.StatusMovedPermanently)
+	}))
+	defer srv.Close()
+	status, target, err := resolveRedirect(srv.URL + "/x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(target)
+	if status != http.StatusMovedPermanently || u.Path != "/landing" || u.Host == "" {
+		t.Errorf("expected an absolute redirect to /landing, got %d %q", status, target)
+	}
+}
@@ -0,0 +1,571 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: shorturl_import.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:43:40
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:04:38
+//
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+// shortImportOpts holds options for importing short URLs in bulk
+var shortImportOpts struct {
+	domain          string
+	format          string
+	results         string
+	batch           batchOpts
+	continueOnError bool
+	resume          bool
+	fromRow         int
+}
+
+// importRow is one input row of an import, numbered from 1 without the CSV
+// header. err is set when the row could not be parsed.
+type importRow struct {
+	row int
+	req seesdk.CreateShortURLRequest
+	// tags are tag names (or IDs) still to be resolved into req.TagIDs
+	tags []string
+	err  error
+}
+
+// importResult is one line of the results file.
+type importResult struct {
+	Row      int    `json:"row"`
+	Target   string `json:"target,omitempty"`
+	ShortURL string `json:"short_url,omitempty"`
+	Slug     string `json:"slug,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// importSummary is what the import command renders once done.
+type importSummary struct {
+	Created int    `json:"created"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"`
+	Results string `json:"results"`
+}
+
+// importColumns maps the accepted column names to CreateShortURLRequest fields.
+var importColumns = map[string]string{
+	"target":                  "target_url",
+	"target_url":              "target_url",
+	"url":                     "target_url",
+	"slug":                    "custom_slug",
+	"custom_slug":             "custom_slug",
+	"domain":                  "domain",
+	"title":                   "title",
+	"password":                "password",
+	"expire_at":               "expire_at",
+	"tags":                    "tags",
+	"tag_ids":                 "tag_ids",
+	"expiration_redirect_url": "expiration_redirect_url",
+}
+
+func init() {
+	shorturlCmd.AddCommand(shorturlImportCmd)
+
+	f := shorturlImportCmd.Flags()
+	f.StringVar(&shortImportOpts.domain, "domain", "s.ee", "Short domain for rows without a domain column")
+	bindSetting(f, "domain", "domain")
+	shorturlImportCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindShortURL))
+	f.StringVar(&shortImportOpts.format, "format", "", "Input format: csv or jsonl (default: from the file extension)")
+	f.StringVar(&shortImportOpts.results, "results", "", "Results file (default: <file>.results.jsonl)")
+	addBatchFlags(shorturlImportCmd, &shortImportOpts.batch)
+	f.BoolVar(&shortImportOpts.continueOnError, "continue-on-error", false, "Keep going after a row fails")
+	f.BoolVar(&shortImportOpts.resume, "resume", false, "Skip rows that already succeeded according to the results file")
+	f.IntVar(&shortImportOpts.fromRow, "from-row", 1, "Start at this row (rows are numbered from 1, without the CSV header)")
+}
+
+var shorturlImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Create short URLs in bulk from a CSV or JSONL file",
+	Long: `Create short URLs in bulk from a CSV or JSONL file ("-" reads stdin).
+
+Columns, or JSON fields: target (or target_url), slug, domain, title, password,
+expire_at (a date as accepted by --expire-at, or unix seconds), tags (tag
+names or IDs separated by ';' or ','), tag_ids and expiration_redirect_url.
+Only target is required.
+
+Every row gets a line in the results file with its short URL or error. An
+interrupted import can be rerun with --resume, which skips the rows that
+already succeeded, or from a given row with --from-row.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := shortImportOpts.batch.check(); err != nil {
+			return err
+		}
+		resultsPath := shortImportOpts.results
+		if resultsPath == "" {
+			if args[0] == "-" {
+				return fmt.Errorf("--results is required when reading from stdin")
+			}
+			resultsPath = args[0] + ".results.jsonl"
+		}
+
+		rows, err := readImportRows(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		resolveImportTags(rows)
+		checkImportDomains(rows)
+
+		done := map[int]bool{}
+		if _, err := os.Stat(resultsPath); err == nil {
+			if !shortImportOpts.resume && !cmd.Flags().Changed("from-row") {
+				return fmt.Errorf("results file %s already exists: use --resume to continue the import or --results to write elsewhere", resultsPath)
+			}
+			if shortImportOpts.resume {
+				if done, err = succeededRows(resultsPath); err != nil {
+					return err
+				}
+			}
+		}
+
+		var todo []importRow
+		for _, r := range rows {
+			if r.row >= shortImportOpts.fromRow && !done[r.row] {
+				todo = append(todo, r)
+			}
+		}
+
+		summary, runErr := runImport(cmd, todo, resultsPath, apiClient.CreateShortURL)
+		summary.Skipped = len(rows) - len(todo)
+		if err := compactResults(resultsPath); err != nil {
+			return err
+		}
+		if err := render(cmd, result{
+			data:    summary,
+			columns: []string{"created", "failed", "skipped", "results"},
+			text: func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "Created %d, failed %d, skipped %d. Results: %s\n",
+					summary.Created, summary.Failed, summary.Skipped, summary.Results)
+				return err
+			},
+		}); err != nil {
+			return err
+		}
+		return runErr
+	},
+}
+
+// runImport creates the links of rows on the batch worker pool and appends
+// a line per row to the results file as soon as the row is done, so that an
+// interrupted import loses nothing. Unless --continue-on-error is set, no new
+// rows are started after a failure.
+func runImport(cmd *cobra.Command, rows []importRow, resultsPath string, create func(seesdk.CreateShortURLRequest) (*seesdk.CreateShortURLResponse, error)) (importSummary, error) {
+	summary := importSummary{Results: resultsPath}
+	out, err := os.OpenFile(resultsPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return summary, err
+	}
+	defer out.Close()
+
+	var (
+		mu       sync.Mutex
+		enc      = json.NewEncoder(out)
+		writeErr error
+		firstErr error
+	)
+	runBatch(shortImportOpts.batch, len(rows), func(i int) importResult {
+		res := importOne(cmd, rows[i], create)
+		mu.Lock()
+		defer mu.Unlock()
+		if err := enc.Encode(res); err != nil && writeErr == nil {
+			writeErr = fmt.Errorf("failed to write results: %w", err)
+		}
+		return res
+	}, func(i int, res importResult) bool {
+		if res.Error == "" {
+			summary.Created++
+			return true
+		}
+		summary.Failed++
+		fmt.Fprintf(cmd.ErrOrStderr(), "row %d: %s\n", res.Row, res.Error)
+		if shortImportOpts.continueOnError {
+			return true
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("import stopped at row %d: rerun with --resume to continue, or use --continue-on-error", res.Row)
+		}
+		return false
+	})
+	if writeErr != nil {
+		return summary, writeErr
+	}
+	if firstErr == nil && summary.Failed > 0 {
+		firstErr = fmt.Errorf("%d of %d rows failed; see %s", summary.Failed, len(rows), resultsPath)
+	}
+	return summary, firstErr
+}
+
+// importOne creates the short URL of a single row.
+func importOne(cmd *cobra.Command, r importRow, create func(seesdk.CreateShortURLRequest) (*seesdk.CreateShortURLResponse, error)) importResult {
+	res := importResult{Row: r.row, Target: r.req.TargetURL}
+	if r.err != nil {
+		res.Error = r.err.Error()
+		return res
+	}
+	resp, err := create(r.req)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.ShortURL = resp.Data.ShortURL
+	res.Slug = resp.Data.Slug
+	ledgerCreated(cmd, ledgerRecord{
+		Kind:     kindShortURL,
+		Domain:   r.req.Domain,
+		Slug:     resp.Data.Slug,
+		URL:      resp.Data.ShortURL,
+		Target:   r.req.TargetURL,
+		Title:    r.req.Title,
+		TagIDs:   r.req.TagIDs,
+		ExpireAt: r.req.ExpireAt,
+	})
+	return res
+}
+
+// readImportRows reads and parses every row of the input file.
+func readImportRows(cmd *cobra.Command, path string) ([]importRow, error) {
+	var in io.Reader
+	if path == "-" {
+		in = cmd.InOrStdin()
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	format := strings.ToLower(shortImportOpts.format)
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".jsonl", ".ndjson", ".json":
+			format = "jsonl"
+		default:
+			format = "csv"
+		}
+	}
+	switch format {
+	case "csv":
+		return parseImportCSV(in, shortImportOpts.domain)
+	case "jsonl", "ndjson":
+		return parseImportJSONL(in, shortImportOpts.domain)
+	default:
+		return nil, fmt.Errorf("invalid --format %q: use csv or jsonl", shortImportOpts.format)
+	}
+}
+
+// parseImportCSV parses CSV input with a header row naming the columns.
+func parseImportCSV(in io.Reader, domain string) ([]importRow, error) {
+	cr := csv.NewReader(in)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("import file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, len(header))
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		field, ok := importColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q in CSV header", h)
+		}
+		fields[i] = field
+	}
+
+	var rows []importRow
+	for n := 1; ; n++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		r := importRow{row: n, req: seesdk.CreateShortURLRequest{Domain: domain}}
+		if err != nil {
+			r.err = err
+			rows = append(rows, r)
+			continue
+		}
+		for i, v := range record {
+			if i >= len(fields) {
+				r.err = fmt.Errorf("row has %d columns, header has %d", len(record), len(fields))
+				break
+			}
+			if err := setImportField(&r, fields[i], v); err != nil {
+				r.err = err
+				break
+			}
+		}
+		r.err = checkImportRow(r)
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+// parseImportJSONL parses one JSON object per line. Blank lines are ignored.
+func parseImportJSONL(in io.Reader, domain string) ([]importRow, error) {
+	var rows []importRow
+	sc := bufio.NewScanner(in)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	n := 0
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		n++
+		r := importRow{row: n, req: seesdk.CreateShortURLRequest{Domain: domain}}
+		r.err = decodeImportObject(line, &r)
+		r.err = checkImportRow(r)
+		rows = append(rows, r)
+	}
+	return rows, sc.Err()
+}
+
+// decodeImportObject fills r from a JSON object.
+func decodeImportObject(line []byte, r *importRow) error {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	for k, v := range obj {
+		field, ok := importColumns[strings.ToLower(k)]
+		if !ok {
+			return fmt.Errorf("unknown field %q", k)
+		}
+		var s string
+		switch v := v.(type) {
+		case nil:
+			continue
+		case string:
+			s = v
+		case json.Number:
+			s = v.String()
+		case []any:
+			parts := make([]string, len(v))
+			for i, e := range v {
+				parts[i] = fmt.Sprint(e)
+			}
+			s = strings.Join(parts, ",")
+		default:
+			return fmt.Errorf("invalid value for %q", k)
+		}
+		if err := setImportField(r, field, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setImportField sets one field of r from its text form. Empty values
+// leave the field unchanged.
+func setImportField(r *importRow, field, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	req := &r.req
+	switch field {
+	case "target_url":
+		req.TargetURL = value
+	case "custom_slug":
+		req.CustomSlug = value
+	case "domain":
+		req.Domain = value
+	case "title":
+		req.Title = value
+	case "password":
+		req.Password = value
+	case "expiration_redirect_url":
+		req.ExpirationRedirectURL = value
+	case "expire_at":
+		now := time.Now()
+		t, err := parseExpireTime(value, now, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid expire_at: %w", err)
+		}
+		if !t.After(now) {
+			return fmt.Errorf("expire_at %s is in the past", value)
+		}
+		req.ExpireAt = t.Unix()
+	case "tag_ids":
+		for _, s := range strings.FieldsFunc(value, func(r rune) bool {
+			return r == ';' || r == ',' || r == ' '
+		}) {
+			id, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid tag ID %q", s)
+			}
+			req.TagIDs = append(req.TagIDs, id)
+		}
+	case "tags":
+		for _, s := range strings.FieldsFunc(value, func(r rune) bool {
+			return r == ';' || r == ','
+		}) {
+			if s = strings.TrimSpace(s); s != "" {
+				r.tags = append(r.tags, s)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveImportTags resolves the tag names of all valid rows, looking up
+// each distinct name once. Rows with unknown tags fail.
+func resolveImportTags(rows []importRow) {
+	ids := map[string]int64{}
+	errs := map[string]error{}
+	for i := range rows {
+		r := &rows[i]
+		for _, name := range r.tags {
+			if r.err != nil {
+				break
+			}
+			if _, ok := ids[name]; !ok && errs[name] == nil {
+				got, err := resolveTags([]string{name})
+				if err != nil {
+					errs[name] = err
+				} else {
+					ids[name] = got[0]
+				}
+			}
+			if r.err = errs[name]; r.err == nil {
+				r.req.TagIDs = append(r.req.TagIDs, ids[name])
+			}
+		}
+	}
+}
+
+// checkImportRow returns the parse error of r, or an error when the row
+// lacks a target.
+func checkImportRow(r importRow) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.req.TargetURL == "" {
+		return errors.New("missing target")
+	}
+	return nil
+}
+
+// checkImportDomains checks the domain of every valid row, looking up each
+// distinct domain once. Rows with unavailable domains fail.
+func checkImportDomains(rows []importRow) {
+	errs := map[string]error{}
+	for i := range rows {
+		r := &rows[i]
+		if r.err != nil {
+			continue
+		}
+		err, ok := errs[r.req.Domain]
+		if !ok {
+			err = checkDomain(kindShortURL, r.req.Domain)
+			errs[r.req.Domain] = err
+		}
+		r.err = err
+	}
+}
+
+// readResults reads the lines of a results file.
+func readResults(path string) ([]importResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []importResult
+	dec := json.NewDecoder(f)
+	for {
+		var res importResult
+		err := dec.Decode(&res)
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid results file %s: %w", path, err)
+		}
+		out = append(out, res)
+	}
+}
+
+// succeededRows returns the rows that a previous run created successfully.
+func succeededRows(path string) (map[int]bool, error) {
+	results, err := readResults(path)
+	if err != nil {
+		return nil, err
+	}
+	done := map[int]bool{}
+	for _, res := range results {
+		if res.Error == "" && res.ShortURL != "" {
+			done[res.Row] = true
+		}
+	}
+	return done, nil
+}
+
+// compactResults rewrites the results file with one line per row in row
+// order. A success recorded by any run wins over later errors; otherwise the
+// latest line of a row is kept.
+func compactResults(path string) error {
+	results, err := readResults(path)
+	if err != nil {
+		return err
+	}
+	byRow := map[int]importResult{}
+	for _, res := range results {
+		if prev, ok := byRow[res.Row]; ok && prev.Error == "" {
+			continue
+		}
+		byRow[res.Row] = res
+	}
+	rows := make([]int, 0, len(byRow))
+	for row := range byRow {
+		rows = append(rows, row)
+	}
+	sort.Ints(rows)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, row := range rows {
+		if err := enc.Encode(byRow[row]); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(path, buf.Bytes(), 0644)
+}
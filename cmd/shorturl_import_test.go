@@ -0,0 +1,222 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: shorturl_import_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:43:40
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:04:38
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	in := "target,slug,domain,expire_at,tags\n" +
+		"https://a.example,a,,4102444800,1;2\n" +
+		"https://b.example,,x.link,,\n" +
+		",c,,,\n" +
+		"https://d.example,,,soon,\n"
+	rows, err := parseImportCSV(strings.NewReader(in), "s.ee")
+	if err != nil {
+		t.Fatalf("parseImportCSV failed: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+
+	want := seesdk.CreateShortURLRequest{
+		TargetURL:  "https://a.example",
+		CustomSlug: "a",
+		Domain:     "s.ee",
+		ExpireAt:   4102444800,
+	}
+	if rows[0].err != nil || !reflect.DeepEqual(rows[0].req, want) || !reflect.DeepEqual(rows[0].tags, []string{"1", "2"}) {
+		t.Errorf("unexpected row 1: %+v %v (%v)", rows[0].req, rows[0].tags, rows[0].err)
+	}
+	if rows[1].req.Domain != "x.link" || rows[1].row != 2 {
+		t.Errorf("expected domain column to override default, got %+v", rows[1])
+	}
+	if rows[2].err == nil {
+		t.Error("expected error for row without target")
+	}
+	if rows[3].err == nil {
+		t.Error("expected error for invalid expire_at")
+	}
+
+	if _, err := parseImportCSV(strings.NewReader("target,nope\n"), "s.ee"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
+
+func TestParseImportJSONL(t *testing.T) {
+	in := `{"target": "https://a.example", "tag_ids": [3, 4], "expire_at": 4102444800}
+
+{"target_url": "https://b.example", "custom_slug": "b", "title": null}
+{"target": "https://c.example", "color": "red"}
+not json
+`
+	rows, err := parseImportJSONL(strings.NewReader(in), "s.ee")
+	if err != nil {
+		t.Fatalf("parseImportJSONL failed: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+	if rows[0].err != nil || !reflect.DeepEqual(rows[0].req.TagIDs, []int64{3, 4}) || rows[0].req.ExpireAt != 4102444800 {
+		t.Errorf("unexpected row 1: %+v (%v)", rows[0].req, rows[0].err)
+	}
+	if rows[1].err != nil || rows[1].row != 2 || rows[1].req.CustomSlug != "b" {
+		t.Errorf("unexpected row 2: %+v (%v)", rows[1], rows[1].err)
+	}
+	if rows[2].err == nil || rows[3].err == nil {
+		t.Error("expected errors for unknown field and invalid JSON")
+	}
+}
+
+func TestResolveImportTags(t *testing.T) {
+	withTestTags(t, seesdk.Tag{ID: 7, Name: "launch"}, seesdk.Tag{ID: 8, Name: "Q4 promo"})
+	rows, err := parseImportCSV(strings.NewReader("target,tags,tag_ids\n"+
+		"https://a,launch;Q4 promo,1\n"+
+		"https://b,,2\n"), "s.ee")
+	if err != nil {
+		t.Fatalf("parseImportCSV failed: %v", err)
+	}
+	resolveImportTags(rows)
+	if rows[0].err != nil || !reflect.DeepEqual(rows[0].req.TagIDs, []int64{1, 7, 8}) {
+		t.Errorf("unexpected row 1 tags: %v (%v)", rows[0].req.TagIDs, rows[0].err)
+	}
+	if rows[1].err != nil || !reflect.DeepEqual(rows[1].req.TagIDs, []int64{2}) {
+		t.Errorf("unexpected row 2 tags: %v (%v)", rows[1].req.TagIDs, rows[1].err)
+	}
+}
+
+func TestRunImport_Resume(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	resultsPath := filepath.Join(t.TempDir(), "links.csv.results.jsonl")
+	shortImportOpts.batch.concurrency = 3
+	shortImportOpts.continueOnError = true
+	defer func() { shortImportOpts.continueOnError = false }()
+
+	rows, err := parseImportCSV(strings.NewReader("target,slug\nhttps://a,a\nhttps://b,b\nhttps://c,c\n"), "s.ee")
+	if err != nil {
+		t.Fatalf("parseImportCSV failed: %v", err)
+	}
+
+	var (
+		mu    sync.Mutex
+		calls []string
+		fail  = map[string]bool{"b": true}
+	)
+	create := func(req seesdk.CreateShortURLRequest) (*seesdk.CreateShortURLResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, req.CustomSlug)
+		if fail[req.CustomSlug] {
+			return nil, errors.New("API error (status 500): boom")
+		}
+		resp := &seesdk.CreateShortURLResponse{}
+		resp.Data.Slug = req.CustomSlug
+		resp.Data.ShortURL = "https://s.ee/" + req.CustomSlug
+		return resp, nil
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetErr(&bytes.Buffer{})
+	summary, err := runImport(cmd, rows, resultsPath, create)
+	if err == nil {
+		t.Error("expected error for failed row")
+	}
+	if summary.Created != 2 || summary.Failed != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if err := compactResults(resultsPath); err != nil {
+		t.Fatalf("compactResults failed: %v", err)
+	}
+
+	// Rerun the failed row only, as --resume does.
+	done, err := succeededRows(resultsPath)
+	if err != nil {
+		t.Fatalf("succeededRows failed: %v", err)
+	}
+	if !done[1] || done[2] || !done[3] {
+		t.Fatalf("unexpected succeeded rows: %v", done)
+	}
+	fail["b"] = false
+	calls = nil
+	if _, err := runImport(cmd, rows[1:2], resultsPath, create); err != nil {
+		t.Fatalf("resumed import failed: %v", err)
+	}
+	if !reflect.DeepEqual(calls, []string{"b"}) {
+		t.Errorf("expected only row 2 to be retried, got %v", calls)
+	}
+	if err := compactResults(resultsPath); err != nil {
+		t.Fatalf("compactResults failed: %v", err)
+	}
+
+	results, err := readResults(resultsPath)
+	if err != nil {
+		t.Fatalf("readResults failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected one line per row, got %d", len(results))
+	}
+	for i, res := range results {
+		if res.Row != i+1 || res.Error != "" || res.ShortURL == "" {
+			t.Errorf("unexpected result line %d: %+v", i, res)
+		}
+	}
+
+	l, err := loadLedger()
+	if err != nil {
+		t.Fatalf("loadLedger failed: %v", err)
+	}
+	if len(l.Records) != 3 {
+		t.Errorf("expected 3 ledger records, got %d", len(l.Records))
+	}
+}
+
+func TestRunImport_StopOnError(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	resultsPath := filepath.Join(t.TempDir(), "results.jsonl")
+	shortImportOpts.batch.concurrency = 1
+
+	var rows []importRow
+	for i := 1; i <= 5; i++ {
+		rows = append(rows, importRow{row: i, req: seesdk.CreateShortURLRequest{TargetURL: "https://x"}})
+	}
+	calls := 0
+	create := func(req seesdk.CreateShortURLRequest) (*seesdk.CreateShortURLResponse, error) {
+		calls++
+		return nil, errors.New("boom")
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetErr(&bytes.Buffer{})
+	if _, err := runImport(cmd, rows, resultsPath, create); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls >= len(rows) {
+		t.Errorf("expected import to stop early, got %d calls", calls)
+	}
+	if _, err := os.Stat(resultsPath); err != nil {
+		t.Errorf("expected results file: %v", err)
+	}
+}
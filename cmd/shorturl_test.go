@@ -0,0 +1,61 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: shorturl_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 16:40:02
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 16:40:02
+//
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+func TestShortURLUpdate_KeepsTitle(t *testing.T) {
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":200,"message":"ok"}`)
+	}))
+	defer srv.Close()
+	prev := apiClient
+	apiClient = seesdk.NewClient(seesdk.Config{BaseURL: srv.URL, APIKey: "k"})
+	defer func() { apiClient = prev }()
+	savedOpts := shortUpdateOpts
+	defer func() { shortUpdateOpts = savedOpts }()
+
+	ledgerCreated(&cobra.Command{}, ledgerRecord{Kind: kindShortURL, Domain: "s.ee", Slug: "blog", Target: "https://example.com/old", Title: "Blog"})
+
+	shortUpdateOpts.domain = "s.ee"
+	shortUpdateOpts.targetURL = "https://example.com/new"
+	shortUpdateOpts.title = ""
+	var out bytes.Buffer
+	shorturlUpdateCmd.SetOut(&out)
+	defer shorturlUpdateCmd.SetOut(nil)
+	if err := shorturlUpdateCmd.RunE(shorturlUpdateCmd, []string{"blog"}); err != nil {
+		t.Fatalf("shorturl update failed: %v", err)
+	}
+
+	l, err := loadLedger()
+	if err != nil {
+		t.Fatalf("loadLedger failed: %v", err)
+	}
+	r := l.find(kindShortURL, "s.ee", "blog")
+	if r == nil || r.Target != "https://example.com/new" || r.Title != "Blog" {
+		t.Errorf("expected the new target and the old title, got %+v", r)
+	}
+}
@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2025 S.EE Development Team
+// Copyright (c) 2025-2026 S.EE Development Team
 //
 // This source code is licensed under the MIT License,
 // which is located in the LICENSE file in the source tree's root directory.
@@ -9,31 +9,260 @@
 // File Created: 2025-12-22 22:29:25
 //
 // Modified By: S.EE Development Team <dev@s.ee>
-// Last Modified: 2025-12-23 11:08:11
+// Last Modified: 2026-10-18 08:52:34
 //
 
 package cmd
 
 import (
 	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	seesdk "github.com/sdotee/sdk.go"
 	"github.com/spf13/cobra"
 )
 
+// tagShowOpts holds options for showing the content of a tag
+var tagShowOpts struct {
+	all bool
+}
+
 var tagsCmd = &cobra.Command{
 	Use:   "tags",
 	Short: "List available tags",
+	Long: `List available tags, or show the content that carries a tag.
+
+The API can only list tags. Creating, renaming and deleting tags is done on
+the S.EE website.`,
+	Args: cobra.NoArgs,
+	RunE: listTags,
+}
+
+var tagsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available tags",
+	Args:  cobra.NoArgs,
+	RunE:  listTags,
+}
+
+var tagsShowCmd = &cobra.Command{
+	Use:   "show <tag>",
+	Short: "Show the short URLs and texts that carry a tag (from the local ledger)",
+	Long: `Show the short URLs and texts that carry a tag, given by name or ID.
+
+The API cannot list content by tag, so this reads the local ledger and only
+knows about content created with this CLI.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeTags(cmd, args, toComplete)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		resp, err := apiClient.GetTags()
+		if err := resolveSettings(cmd); err != nil {
+			return err
+		}
+		ids, err := resolveTags(args)
 		if err != nil {
 			return err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp.Data.Tags)
+		l, err := loadLedger()
+		if err != nil {
+			return err
 		}
-		for _, t := range resp.Data.Tags {
-			fmt.Fprintf(cmd.OutOrStdout(), "%d\t%s\n", t.ID, t.Name)
+
+		records := []ledgerRecord{}
+		counts := map[string]int{}
+		// Tag IDs belong to the account, so other profiles' records with
+		// the same IDs are not tagged with this tag.
+		f := ledgerFilter{tagIDs: ids, all: tagShowOpts.all, sortBy: "created", profile: activeProfile}
+		for _, kind := range []string{kindShortURL, kindText} {
+			found, err := f.apply(l.Records, kind, time.Now())
+			if err != nil {
+				return err
+			}
+			counts[kind] = len(found)
+			records = append(records, found...)
 		}
-		return nil
+
+		return render(cmd, result{
+			data:    records,
+			columns: []string{"kind", "id", "url", "target", "title", "created_at"},
+			text: func(out io.Writer) error {
+				fmt.Fprintf(out, "Tag %s (ID %d): %d short URL(s), %d text(s)\n", args[0], ids[0], counts[kindShortURL], counts[kindText])
+				if len(records) == 0 {
+					return nil
+				}
+				w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+				fmt.Fprintln(w, "\nKIND\tURL\tTARGET\tTITLE\tCREATED")
+				for _, r := range records {
+					url := r.URL
+					if url == "" {
+						url = r.Domain + "/" + r.Slug
+					}
+					if r.DeletedAt != nil {
+						url += " (deleted)"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Kind, url, r.Target, r.Title, r.CreatedAt.Local().Format("2006-01-02 15:04"))
+				}
+				return w.Flush()
+			},
+		})
 	},
 }
+
+// Tags can only be managed on the website. These commands exist so that
+// trying them explains that, rather than failing as unknown commands.
+var (
+	tagsCreateCmd = websiteTagCmd("create <name>", "Create a tag (on the S.EE website only)", "created")
+	tagsRenameCmd = websiteTagCmd("rename <tag> <new-name>", "Rename a tag (on the S.EE website only)", "renamed")
+	tagsDeleteCmd = websiteTagCmd("delete <tag>", "Delete a tag (on the S.EE website only)", "deleted")
+)
+
+func init() {
+	tagsCmd.AddCommand(tagsListCmd)
+	tagsCmd.AddCommand(tagsShowCmd)
+	tagsCmd.AddCommand(tagsCreateCmd)
+	tagsCmd.AddCommand(tagsRenameCmd)
+	tagsCmd.AddCommand(tagsDeleteCmd)
+
+	tagsShowCmd.Flags().BoolVar(&tagShowOpts.all, "all", false, "Include deleted entries")
+}
+
+// websiteTagCmd returns a tags subcommand for an operation the API does not
+// offer. It accepts any arguments and flags and fails with where to do it.
+func websiteTagCmd(use, short, done string) *cobra.Command {
+	return &cobra.Command{
+		Use:                use,
+		Short:              short,
+		DisableFlagParsing: true,
+		Annotations:        map[string]string{skipClientAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("tags cannot be %s through the API: manage tags on the S.EE website", done)
+		},
+	}
+}
+
+// listTags prints the tags of the account.
+func listTags(cmd *cobra.Command, args []string) error {
+	tags, err := fetchTags()
+	if err != nil {
+		return err
+	}
+	return render(cmd, result{
+		data:    tags,
+		columns: []string{"id", "name"},
+		text: func(w io.Writer) error {
+			for _, t := range tags {
+				fmt.Fprintf(w, "%d\t%s\n", t.ID, t.Name)
+			}
+			return nil
+		},
+	})
+}
+
+// getTags gets the tags from the API.
+func getTags() ([]seesdk.Tag, error) {
+	resp, err := apiClient.GetTags()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data.Tags, nil
+}
+
+// fetchTags gets the tags from the API and refreshes the tag cache.
+func fetchTags() ([]seesdk.Tag, error) {
+	return refreshCache("tags", getTags)
+}
+
+// cachedTags returns the tags from the cache, or from the API when the cache
+// is missing or stale. cached reports whether the cache was used.
+func cachedTags() ([]seesdk.Tag, bool, error) {
+	return cachedValue("tags", getTags)
+}
+
+// resolveTags turns tag names into tag IDs. Values that name no tag but are
+// numbers are taken as IDs, so --tag accepts both. A cached tag list is
+// refreshed once before a name is reported as unknown.
+func resolveTags(values []string) ([]int64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	tags, cached, err := cachedTags()
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve tag names: %w", err)
+	}
+
+	var ids []int64
+	for _, v := range values {
+		id, ok := lookupTag(tags, v)
+		if !ok && cached {
+			if tags, err = fetchTags(); err != nil {
+				return nil, fmt.Errorf("cannot resolve tag names: %w", err)
+			}
+			cached = false
+			id, ok = lookupTag(tags, v)
+		}
+		if !ok {
+			return nil, unknownTagError(tags, v)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// lookupTag finds a tag by exact name, then by case-insensitive name, then by
+// numeric ID.
+func lookupTag(tags []seesdk.Tag, v string) (int64, bool) {
+	v = strings.TrimSpace(v)
+	for _, t := range tags {
+		if t.Name == v {
+			return int64(t.ID), true
+		}
+	}
+	for _, t := range tags {
+		if strings.EqualFold(t.Name, v) {
+			return int64(t.ID), true
+		}
+	}
+	if id, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return id, true
+	}
+	return 0, false
+}
+
+// unknownTagError explains that a tag does not exist. The API has no
+// endpoint to create tags, so they have to be created on the website.
+func unknownTagError(tags []seesdk.Tag, name string) error {
+	if len(tags) == 0 {
+		return fmt.Errorf("unknown tag %q: the account has no tags; create them on the S.EE website first", name)
+	}
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown tag %q (available: %s); tags cannot be created through the API, create it on the S.EE website first", name, strings.Join(names, ", "))
+}
+
+// completeTags completes tag names for --tag. Values are comma-separated, so
+// the names already typed are kept as prefix.
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tags := completionCache[[]seesdk.Tag](cmd, "tags")
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	var out []string
+	for _, t := range tags {
+		out = append(out, prefix+t.Name)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
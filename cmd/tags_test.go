@@ -0,0 +1,134 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: tags_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 08:49:55
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 08:50:39
+//
+
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+// withTestTags points the cache at a temporary directory and fills the tag
+// cache, so that tag names resolve without the API.
+func withTestTags(t *testing.T, tags ...seesdk.Tag) {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	activeProfile = defaultProfileName
+	if tags == nil {
+		tags = []seesdk.Tag{}
+	}
+	if err := writeCache("tags", tags); err != nil {
+		t.Fatalf("writeCache failed: %v", err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	withTestTags(t, seesdk.Tag{ID: 1, Name: "a"})
+
+	var tags []seesdk.Tag
+	if !readCache("tags", time.Hour, &tags) || len(tags) != 1 {
+		t.Fatalf("expected cached tags, got %v", tags)
+	}
+	if readCache("tags", 0, &tags) {
+		t.Error("expected stale cache to miss")
+	}
+
+	baseURL := rootOpts.baseURL
+	rootOpts.baseURL = "https://other.example/api/v1"
+	defer func() { rootOpts.baseURL = baseURL }()
+	if readCache("tags", time.Hour, &tags) {
+		t.Error("expected cache of another base URL to miss")
+	}
+}
+
+func TestResolveTags(t *testing.T) {
+	withTestTags(t,
+		seesdk.Tag{ID: 1, Name: "launch"},
+		seesdk.Tag{ID: 2, Name: "Launch"},
+		seesdk.Tag{ID: 3, Name: "Q4 promo"},
+		seesdk.Tag{ID: 4, Name: "2024"},
+	)
+
+	ids, err := resolveTags([]string{"Launch", "q4 promo", "2024", "17"})
+	if err != nil {
+		t.Fatalf("resolveTags failed: %v", err)
+	}
+	if want := []int64{2, 3, 4, 17}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+
+	if ids, err := resolveTags(nil); err != nil || ids != nil {
+		t.Errorf("expected no tags, got %v (%v)", ids, err)
+	}
+}
+
+func TestUnknownTagError(t *testing.T) {
+	err := unknownTagError([]seesdk.Tag{{ID: 2, Name: "b"}, {ID: 1, Name: "a"}}, "c")
+	if !strings.Contains(err.Error(), `unknown tag "c" (available: a, b)`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCompleteTags(t *testing.T) {
+	withTestTags(t, seesdk.Tag{ID: 1, Name: "launch"}, seesdk.Tag{ID: 2, Name: "promo"})
+	withTestConfig(t, "")
+
+	got, directive := completeTags(&cobra.Command{}, nil, "launch,pr")
+	if want := []string{"launch,launch", "launch,promo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if directive&cobra.ShellCompDirectiveNoFileComp == 0 {
+		t.Error("expected file completion to be disabled")
+	}
+}
+
+func TestTagsShow(t *testing.T) {
+	withTestTags(t, seesdk.Tag{ID: 1, Name: "launch"}, seesdk.Tag{ID: 2, Name: "other"})
+	withTestConfig(t, "")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	cmd := &cobra.Command{}
+	ledgerCreated(cmd, ledgerRecord{Kind: kindShortURL, Domain: "s.ee", Slug: "a", TagIDs: []int64{1, 2}})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindText, Domain: "s.ee", Slug: "b", TagIDs: []int64{1}})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindShortURL, Domain: "s.ee", Slug: "c", TagIDs: []int64{2}})
+	ledgerCreated(cmd, ledgerRecord{Kind: kindFile, Filename: "x.png"})
+
+	var out bytes.Buffer
+	tagsShowCmd.SetOut(&out)
+	defer tagsShowCmd.SetOut(nil)
+	if err := tagsShowCmd.RunE(tagsShowCmd, []string{"launch"}); err != nil {
+		t.Fatalf("tags show failed: %v", err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "Tag launch (ID 1): 1 short URL(s), 1 text(s)") {
+		t.Errorf("unexpected summary: %q", got)
+	}
+	if !strings.Contains(got, "s.ee/a") || !strings.Contains(got, "s.ee/b") || strings.Contains(got, "s.ee/c") {
+		t.Errorf("unexpected entries: %q", got)
+	}
+}
+
+func TestTagsWebsiteOnly(t *testing.T) {
+	for _, c := range []*cobra.Command{tagsCreateCmd, tagsRenameCmd, tagsDeleteCmd} {
+		err := c.RunE(c, []string{"launch", "--color", "red"})
+		if err == nil || !strings.Contains(err.Error(), "S.EE website") {
+			t.Errorf("%s: expected an error pointing to the website, got %v", c.Name(), err)
+		}
+	}
+}
@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2025 S.EE Development Team
+// Copyright (c) 2025-2026 S.EE Development Team
 //
 // This source code is licensed under the MIT License,
 // which is located in the LICENSE file in the source tree's root directory.
@@ -9,13 +9,17 @@
 // File Created: 2025-12-22 22:27:43
 //
 // Modified By: S.EE Development Team <dev@s.ee>
-// Last Modified: 2025-12-23 11:08:14
+// Last Modified: 2026-10-18 09:21:10
 //
 
 package cmd
 
 import (
 	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
 
 	seesdk "github.com/sdotee/sdk.go"
 	"github.com/spf13/cobra"
@@ -24,27 +28,41 @@ import (
 var (
 	// textCreateOpts holds options for creating a text entry
 	textCreateOpts struct {
-		domain   string
-		slug     string
-		title    string
-		textType string
-		password string
-		expireAt int64
-		tagIDs   []int64
-		file     string
+		domain      string
+		slug        string
+		title       string
+		textType    string
+		password    string
+		expiry      expiryOpts
+		tagIDs      []int64
+		tags        []string
+		file        string
+		encrypt     bool
+		separateKey bool
 	}
 
 	// textUpdateOpts holds options for updating a text entry
 	textUpdateOpts struct {
+		domain  string
+		title   string
+		file    string
+		encrypt bool
+		key     string
+	}
+
+	// textGetOpts holds options for reading a text entry
+	textGetOpts struct {
 		domain string
-		title  string
-		file   string
+		key    string
 	}
 
 	// textDeleteOpts holds options for deleting a text entry
 	textDeleteOpts struct {
 		domain string
 	}
+
+	// textListOpts holds options for listing text entries from the ledger
+	textListOpts ledgerFilter
 )
 
 var textCmd = &cobra.Command{
@@ -55,63 +73,263 @@ var textCmd = &cobra.Command{
 func init() {
 	textCmd.AddCommand(textCreateCmd)
 	textCmd.AddCommand(textUpdateCmd)
+	textCmd.AddCommand(textGetCmd)
 	textCmd.AddCommand(textDeleteCmd)
+	textCmd.AddCommand(textListCmd)
+	textCmd.AddCommand(textDomainsCmd)
 
 	textCreateCmd.Flags().StringVar(&textCreateOpts.domain, "domain", "s.ee", "Short domain")
+	bindSetting(textCreateCmd.Flags(), "domain", "domain")
+	textCreateCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindText))
 	textCreateCmd.Flags().StringVar(&textCreateOpts.slug, "slug", "", "Custom slug")
 	textCreateCmd.Flags().StringVar(&textCreateOpts.title, "title", "", "Title")
-	textCreateCmd.Flags().StringVar(&textCreateOpts.textType, "type", "", "Syntax highlighting type")
+	textCreateCmd.Flags().StringVar(&textCreateOpts.textType, "type", "", "Syntax highlighting language, e.g. go")
 	textCreateCmd.Flags().StringVar(&textCreateOpts.password, "password", "", "Password")
-	textCreateCmd.Flags().Int64Var(&textCreateOpts.expireAt, "expire-at", 0, "Expire at (unix seconds)")
 	textCreateCmd.Flags().Int64SliceVar(&textCreateOpts.tagIDs, "tag-ids", nil, "Tag IDs")
+	textCreateCmd.RegisterFlagCompletionFunc("tag-ids", completeTagIDs)
+	textCreateCmd.Flags().StringSliceVar(&textCreateOpts.tags, "tag", nil, "Tag names (or IDs), comma-separated")
+	textCreateCmd.RegisterFlagCompletionFunc("tag", completeTags)
 	textCreateCmd.Flags().StringVar(&textCreateOpts.file, "file", "-", "Input file path, or '-' for stdin")
+	addExpiryFlags(textCreateCmd, &textCreateOpts.expiry)
+	textCreateCmd.Flags().BoolVar(&textCreateOpts.encrypt, "encrypt", false, "Encrypt the content locally; the key is printed as the URL's #fragment")
+	textCreateCmd.Flags().BoolVar(&textCreateOpts.separateKey, "separate-key", false, "With --encrypt, print the key on its own line instead of in the URL")
 
+	textUpdateCmd.ValidArgsFunction = completeSlugs(kindText)
 	textUpdateCmd.Flags().StringVar(&textUpdateOpts.domain, "domain", "s.ee", "Short domain")
+	bindSetting(textUpdateCmd.Flags(), "domain", "domain")
+	textUpdateCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindText))
 	textUpdateCmd.Flags().StringVar(&textUpdateOpts.title, "title", "", "Title")
 	textUpdateCmd.Flags().StringVar(&textUpdateOpts.file, "file", "-", "Input file path, or '-' for stdin")
+	textUpdateCmd.Flags().BoolVar(&textUpdateOpts.encrypt, "encrypt", false, "Encrypt the content locally with a new key, printed as the URL's #fragment")
+	textUpdateCmd.Flags().StringVar(&textUpdateOpts.key, "key", "", "Encrypt the content locally with this key, as a token or the URL ending in #key")
+
+	textGetCmd.ValidArgsFunction = completeSlugs(kindText)
+	textGetCmd.Flags().StringVar(&textGetOpts.domain, "domain", "s.ee", "Short domain")
+	bindSetting(textGetCmd.Flags(), "domain", "domain")
+	textGetCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindText))
+	textGetCmd.Flags().StringVar(&textGetOpts.key, "key", "", "Key of an encrypted paste, as a token or the URL ending in #key")
 
+	textDeleteCmd.ValidArgsFunction = completeSlugs(kindText)
 	textDeleteCmd.Flags().StringVar(&textDeleteOpts.domain, "domain", "s.ee", "Short domain")
+	bindSetting(textDeleteCmd.Flags(), "domain", "domain")
+	textDeleteCmd.RegisterFlagCompletionFunc("domain", completeDomains(kindText))
+
+	addLedgerFilterFlags(textListCmd, &textListOpts)
 }
 
 var textCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a text entry (reads from --file or stdin)",
-	Args:  cobra.NoArgs,
+	Long: `Create a text entry (reads from --file or stdin).
+
+With --encrypt the content is encrypted with AES-256-GCM and a random key
+before it leaves this machine, and the key is printed as the #fragment of the
+URL, which browsers and the CLI never send to the server. Read the paste with
+'see text get <url>#<key>' or 'see text get <slug> --key <key>'. The title
+is not encrypted.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if textCreateOpts.encrypt && textCreateOpts.textType != "" {
+			return fmt.Errorf("cannot use --type with --encrypt: the server only sees ciphertext")
+		}
+		if textCreateOpts.separateKey && !textCreateOpts.encrypt {
+			return fmt.Errorf("--separate-key needs --encrypt")
+		}
+		expireAt, err := textCreateOpts.expiry.expireAt(time.Now())
+		if err != nil {
+			return err
+		}
+		tagIDs, err := resolveTags(textCreateOpts.tags)
+		if err != nil {
+			return err
+		}
+		if err := checkDomain(kindText, textCreateOpts.domain); err != nil {
+			return err
+		}
 		content, err := readContent(textCreateOpts.file, cmd)
 		if err != nil {
 			return err
 		}
-		resp, err := apiClient.CreateText(seesdk.CreateTextRequest{
+		var key []byte
+		if textCreateOpts.encrypt {
+			if key, err = newKey(); err != nil {
+				return err
+			}
+			if content, err = sealText(key, []byte(content)); err != nil {
+				return err
+			}
+		}
+		req := seesdk.CreateTextRequest{
 			Content:    content,
 			Domain:     textCreateOpts.domain,
 			CustomSlug: textCreateOpts.slug,
 			Title:      textCreateOpts.title,
 			TextType:   textCreateOpts.textType,
 			Password:   textCreateOpts.password,
-			ExpireAt:   textCreateOpts.expireAt,
-			TagIDs:     textCreateOpts.tagIDs,
+			ExpireAt:   expireAt,
+			TagIDs:     append(textCreateOpts.tagIDs, tagIDs...),
+		}
+		resp, err := apiClient.CreateText(req)
+		if err != nil {
+			return err
+		}
+		ledgerCreated(cmd, ledgerRecord{
+			Kind:      kindText,
+			Domain:    req.Domain,
+			Slug:      resp.Data.Slug,
+			URL:       resp.Data.ShortURL,
+			Title:     req.Title,
+			TextType:  req.TextType,
+			TagIDs:    req.TagIDs,
+			ExpireAt:  req.ExpireAt,
+			Encrypted: key != nil,
 		})
+		if key != nil {
+			return renderEncryptedText(cmd, resp.Data.ShortURL, resp.Data.Slug, encodeKey(key), textCreateOpts.separateKey)
+		}
+		return render(cmd, result{
+			data:    resp.Data,
+			columns: []string{"short_url", "slug"},
+			text: func(w io.Writer) error {
+				_, err := fmt.Fprintln(w, resp.Data.ShortURL)
+				return err
+			},
+		})
+	},
+}
+
+// encryptedText is what text create --encrypt renders.
+type encryptedText struct {
+	ShortURL string `json:"short_url"`
+	Slug     string `json:"slug"`
+	Key      string `json:"key"`
+	// KeyURL is the short URL with the key as its fragment
+	KeyURL string `json:"key_url"`
+}
+
+// renderEncryptedText prints the URL of an encrypted paste with its key, in
+// the URL or, with separateKey, on its own line.
+func renderEncryptedText(cmd *cobra.Command, shortURL, slug, key string, separateKey bool) error {
+	data := encryptedText{ShortURL: shortURL, Slug: slug, Key: key, KeyURL: shortURL + "#" + key}
+	return render(cmd, result{
+		data:    data,
+		columns: []string{"short_url", "slug", "key"},
+		text: func(w io.Writer) error {
+			if separateKey {
+				_, err := fmt.Fprintf(w, "%s\nKey: %s\n", data.ShortURL, data.Key)
+				return err
+			}
+			_, err := fmt.Fprintln(w, data.KeyURL)
+			return err
+		},
+	})
+}
+
+var textGetCmd = &cobra.Command{
+	Use:   "get <slug|url>",
+	Short: "Print the raw content of a text entry",
+	Long: `Print the raw content of a text entry, given by slug or URL, so that it
+can be piped into diff or an editor.
+
+The API has no endpoint that reads a paste, so the paste's URL is fetched
+asking for plain text. When the server answers with a web page instead, text
+get fails rather than guess at the content from the page's markup.
+
+An encrypted paste is decrypted locally with the key given by --key or as the
+#fragment of the URL. The key is never sent to the server.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveSettings(cmd); err != nil {
+			return err
+		}
+		target, fragment, _ := strings.Cut(contentURL(textGetOpts.domain, args[0]), "#")
+		keyText := textGetOpts.key
+		if keyText == "" {
+			keyText = fragment
+		}
+		body, contentType, err := fetchContent(target)
 		if err != nil {
 			return err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp.Data)
+		content, ok := pasteContent(body, contentType)
+		envelope := textEnvelope(content)
+		switch {
+		case !ok:
+			return fmt.Errorf("%s returned a web page instead of the raw paste: only pastes served as plain text can be read (a password-protected or expired paste is also shown as a page)", target)
+		case envelope != "" && keyText == "":
+			return fmt.Errorf("%s is encrypted: pass --key or the URL ending in #key", target)
+		case envelope == "" && keyText != "":
+			return fmt.Errorf("%s is not an encrypted paste", target)
+		case envelope != "":
+			key, err := parseKey(keyText)
+			if err != nil {
+				return err
+			}
+			if content, err = openText(key, envelope); err != nil {
+				return err
+			}
 		}
-		fmt.Fprintln(cmd.OutOrStdout(), resp.Data.ShortURL)
-		return nil
+		return render(cmd, result{
+			data:    textContent{URL: target, Slug: path.Base(target), Content: string(content)},
+			columns: []string{"url", "content"},
+			text: func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			},
+		})
 	},
 }
 
+// textContent is what text get renders.
+type textContent struct {
+	URL     string `json:"url"`
+	Slug    string `json:"slug"`
+	Content string `json:"content"`
+}
+
 var textUpdateCmd = &cobra.Command{
 	Use:   "update <slug>",
 	Short: "Update a text entry (reads from --file or stdin)",
-	Args:  cobra.ExactArgs(1),
+	Long: `Update a text entry (reads from --file or stdin).
+
+A paste created with --encrypt can only be updated with --key, which encrypts
+the new content with the paste's key, or --encrypt, which encrypts it with a
+new key. Either also encrypts a paste that was not encrypted before.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if textUpdateOpts.encrypt && textUpdateOpts.key != "" {
+			return fmt.Errorf("cannot use --encrypt with --key: --encrypt makes a new key")
+		}
+		var key []byte
+		var err error
+		switch {
+		case textUpdateOpts.key != "":
+			if key, err = parseKey(textUpdateOpts.key); err != nil {
+				return err
+			}
+		case textUpdateOpts.encrypt:
+			if key, err = newKey(); err != nil {
+				return err
+			}
+		default:
+			l, err := loadLedger()
+			if err != nil {
+				return err
+			}
+			if r := l.find(kindText, textUpdateOpts.domain, args[0]); r != nil && r.Encrypted {
+				return fmt.Errorf("%s/%s is encrypted: pass --key to encrypt the new content with its key, or --encrypt for a new key", textUpdateOpts.domain, args[0])
+			}
+		}
 		content, err := readContent(textUpdateOpts.file, cmd)
 		if err != nil {
 			return err
 		}
+		if key != nil {
+			if content, err = sealText(key, []byte(content)); err != nil {
+				return err
+			}
+		}
 		resp, err := apiClient.UpdateText(seesdk.UpdateTextRequest{
 			Domain:  textUpdateOpts.domain,
 			Slug:    args[0],
@@ -121,13 +339,21 @@ var textUpdateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp)
-		}
-		if resp.Message != "" {
-			fmt.Fprintln(cmd.OutOrStdout(), resp.Message)
+		var shortURL string
+		ledgerUpdated(cmd, kindText, textUpdateOpts.domain, args[0], func(r *ledgerRecord) {
+			if textUpdateOpts.title != "" {
+				r.Title = textUpdateOpts.title
+			}
+			r.Encrypted = key != nil
+			shortURL = r.URL
+		})
+		if textUpdateOpts.encrypt {
+			if shortURL == "" {
+				shortURL = contentURL(textUpdateOpts.domain, args[0])
+			}
+			return renderEncryptedText(cmd, shortURL, args[0], encodeKey(key), false)
 		}
-		return nil
+		return render(cmd, messageResult(resp, resp.Message))
 	},
 }
 
@@ -143,12 +369,17 @@ var textDeleteCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if rootOpts.jsonOutput {
-			return printJSON(cmd.OutOrStdout(), resp)
-		}
-		if resp.Message != "" {
-			fmt.Fprintln(cmd.OutOrStdout(), resp.Message)
-		}
-		return nil
+		ledgerDeleted(cmd, kindText, textDeleteOpts.domain, args[0])
+		return render(cmd, messageResult(resp, resp.Message))
+	},
+}
+
+var textListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List text entries created with this CLI (from the local ledger)",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{skipClientAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listLedger(cmd, kindText, &textListOpts)
 	},
 }
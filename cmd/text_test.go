@@ -0,0 +1,167 @@
+//
+// Copyright (c) 2026 S.EE Development Team
+//
+// This source code is licensed under the MIT License,
+// which is located in the LICENSE file in the source tree's root directory.
+//
+// File: text_test.go
+// Author: S.EE Development Team <dev@s.ee>
+// File Created: 2026-10-18 09:13:50
+//
+// Modified By: S.EE Development Team <dev@s.ee>
+// Last Modified: 2026-10-18 09:21:10
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	seesdk "github.com/sdotee/sdk.go"
+	"github.com/spf13/cobra"
+)
+
+// withTestPastes serves pastes at their paths as plain text.
+func withTestPastes(t *testing.T, pastes map[string]string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := pastes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, content)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestTextGetEncrypted(t *testing.T) {
+	withTestConfig(t, "")
+	key, _ := newKey()
+	envelope, err := sealText(key, []byte("line 1\nline 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := "Encrypted pastes look like " + envelope + "\n"
+	base := withTestPastes(t, map[string]string{"/enc": envelope, "/docs": docs})
+	token := encodeKey(key)
+
+	var out bytes.Buffer
+	textGetCmd.SetOut(&out)
+	defer textGetCmd.SetOut(nil)
+	textGetOpts.key = ""
+	if err := textGetCmd.RunE(textGetCmd, []string{base + "/enc#" + token}); err != nil {
+		t.Fatalf("text get failed: %v", err)
+	}
+	if out.String() != "line 1\nline 2\n" {
+		t.Errorf("expected the decrypted text, got %q", out.String())
+	}
+
+	out.Reset()
+	textGetOpts.key = token
+	defer func() { textGetOpts.key = "" }()
+	if err := textGetCmd.RunE(textGetCmd, []string{base + "/enc"}); err != nil {
+		t.Fatalf("text get --key failed: %v", err)
+	}
+	if out.String() != "line 1\nline 2\n" {
+		t.Errorf("expected the decrypted text, got %q", out.String())
+	}
+
+	textGetOpts.key = ""
+	err = textGetCmd.RunE(textGetCmd, []string{base + "/enc"})
+	if err == nil || !strings.Contains(err.Error(), "is encrypted") {
+		t.Errorf("expected error asking for the key, got %v", err)
+	}
+
+	// A paste served as a page is not guessed at.
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<html><body><pre>line 1</pre></body></html>")
+	}))
+	defer page.Close()
+	err = textGetCmd.RunE(textGetCmd, []string{page.URL + "/abc"})
+	if err == nil || !strings.Contains(err.Error(), "web page instead of the raw paste") {
+		t.Errorf("expected a page to be refused, got %v", err)
+	}
+
+	// A plaintext paste that mentions an envelope is printed as is.
+	out.Reset()
+	if err := textGetCmd.RunE(textGetCmd, []string{base + "/docs"}); err != nil {
+		t.Fatalf("text get of a plaintext paste failed: %v", err)
+	}
+	if out.String() != docs {
+		t.Errorf("expected the plaintext, got %q", out.String())
+	}
+}
+
+func TestTextUpdateEncrypted(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req seesdk.UpdateTextRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		sent = append(sent, req.Content)
+		io.WriteString(w, `{"code":200,"message":"ok"}`)
+	}))
+	defer srv.Close()
+	prev := apiClient
+	apiClient = seesdk.NewClient(seesdk.Config{BaseURL: srv.URL, APIKey: "k"})
+	defer func() { apiClient = prev }()
+	ledgerCreated(&cobra.Command{}, ledgerRecord{Kind: kindText, Domain: "s.ee", Slug: "secret", URL: "https://s.ee/secret", Encrypted: true})
+
+	run := func(key string) error {
+		textUpdateOpts.domain, textUpdateOpts.key, textUpdateOpts.file = "s.ee", key, "-"
+		textUpdateCmd.SetIn(strings.NewReader("new content"))
+		textUpdateCmd.SetOut(io.Discard)
+		return textUpdateCmd.RunE(textUpdateCmd, []string{"secret"})
+	}
+	defer func() {
+		textUpdateOpts.key = ""
+		textUpdateCmd.SetIn(nil)
+		textUpdateCmd.SetOut(nil)
+	}()
+
+	// Without a key the plaintext is never sent.
+	if err := run(""); err == nil || !strings.Contains(err.Error(), "is encrypted") {
+		t.Fatalf("expected the update to be refused, got %v", err)
+	}
+	if len(sent) != 0 {
+		t.Fatalf("expected nothing to be sent, got %q", sent)
+	}
+
+	key, _ := newKey()
+	if err := run("https://s.ee/secret#" + encodeKey(key)); err != nil {
+		t.Fatalf("text update --key failed: %v", err)
+	}
+	if len(sent) != 1 || !strings.HasPrefix(sent[0], textEnvelopePrefix) {
+		t.Fatalf("expected an envelope to be sent, got %q", sent)
+	}
+	if got, err := openText(key, sent[0]); err != nil || string(got) != "new content" {
+		t.Errorf("expected the new content under the key, got %q, %v", got, err)
+	}
+}
+
+func TestPasteContent(t *testing.T) {
+	tests := []struct {
+		name, body, contentType, want string
+		ok                            bool
+	}{
+		{"plain", "a < b\n", "text/plain; charset=utf-8", "a < b\n", true},
+		{"markdown", "# Notes\n", "text/markdown", "# Notes\n", true},
+		{"page", "<html><pre>if a &lt; b {\n}</pre></html>", "text/html; charset=utf-8", "", false},
+		{"password", "<html><form>password</form></html>", "text/html", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := pasteContent([]byte(tt.body), tt.contentType)
+		if ok != tt.ok || string(got) != tt.want {
+			t.Errorf("%s: got %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}